@@ -3,7 +3,9 @@ module github.com/sbercloud-terraform/terraform-provider-sbercloud
 go 1.12
 
 require (
+	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/terraform-plugin-sdk v1.16.0
 	github.com/huaweicloud/golangsdk v0.0.0-20210621093751-3dd439dd31e3
 	github.com/huaweicloud/terraform-provider-huaweicloud v1.25.2-0.20210629062920-6f6ae914c3ea
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,205 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// cbrBackup is a backup (checkpoint item) returned by the CBR list backups API.
+type cbrBackup struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	CheckpointID string `json:"checkpoint_id"`
+	VaultID      string `json:"vault_id"`
+	ResourceID   string `json:"resource_id"`
+	ResourceName string `json:"resource_name"`
+	ResourceType string `json:"resource_type"`
+	ResourceSize int    `json:"resource_size"`
+	ImageType    string `json:"image_type"`
+	Status       string `json:"status"`
+	CreatedAt    string `json:"created_at"`
+	ExpiredAt    string `json:"expired_at"`
+}
+
+// cbrBackupsPageLimit is the maximum page size accepted by the list backups API.
+const cbrBackupsPageLimit = 100
+
+func DataSourceCBRBackupsV3() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCBRBackupsV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"vault_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"OS::Nova::Server", "OS::Cinder::Volume", "OS::Sfs::Turbo",
+				}, false),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"checkpoint_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vault_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"image_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expired_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCBRBackupsV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.CbrV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+	}
+
+	query := url.Values{}
+	for _, filter := range []string{"vault_id", "resource_id", "resource_type", "name", "status"} {
+		if v, ok := d.GetOk(filter); ok {
+			query.Set(filter, v.(string))
+		}
+	}
+	query.Set("limit", fmt.Sprintf("%d", cbrBackupsPageLimit))
+
+	allBackups := make([]cbrBackup, 0)
+	for offset := 0; ; offset += cbrBackupsPageLimit {
+		query.Set("offset", fmt.Sprintf("%d", offset))
+		listURL := client.ServiceURL("backups") + "?" + query.Encode()
+
+		var rst golangsdk.Result
+		_, rst.Err = client.Get(listURL, &rst.Body, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if rst.Err != nil {
+			return fmt.Errorf("Error querying SberCloud CBR backups: %s", rst.Err)
+		}
+
+		var page struct {
+			Backups []cbrBackup `json:"backups"`
+			Count   int         `json:"count"`
+		}
+		if err := rst.ExtractInto(&page); err != nil {
+			return fmt.Errorf("Error extracting SberCloud CBR backups: %s", err)
+		}
+
+		allBackups = append(allBackups, page.Backups...)
+		if len(page.Backups) < cbrBackupsPageLimit || len(allBackups) >= page.Count {
+			break
+		}
+	}
+	log.Printf("[DEBUG] Retrieved %d SberCloud CBR backups", len(allBackups))
+
+	ids := make([]string, 0, len(allBackups))
+	backups := make([]map[string]interface{}, 0, len(allBackups))
+	for _, b := range allBackups {
+		ids = append(ids, b.ID)
+		backups = append(backups, map[string]interface{}{
+			"id":            b.ID,
+			"name":          b.Name,
+			"description":   b.Description,
+			"checkpoint_id": b.CheckpointID,
+			"vault_id":      b.VaultID,
+			"resource_id":   b.ResourceID,
+			"resource_name": b.ResourceName,
+			"resource_type": b.ResourceType,
+			"resource_size": b.ResourceSize,
+			"image_type":    b.ImageType,
+			"status":        b.Status,
+			"created_at":    b.CreatedAt,
+			"expired_at":    b.ExpiredAt,
+		})
+	}
+
+	d.SetId(hashcode.Strings(ids))
+	d.Set("region", region)
+	if err := d.Set("backups", backups); err != nil {
+		return fmt.Errorf("Error setting CBR backups: %s", err)
+	}
+
+	return nil
+}
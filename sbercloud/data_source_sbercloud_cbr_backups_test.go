@@ -0,0 +1,60 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccCBRV3BackupsDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_cbr_backups.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRV3BackupsDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3BackupsDataSourceID(dataSourceName),
+					resource.TestCheckResourceAttr(dataSourceName, "backups.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCBRV3BackupsDataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find CBR backups data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("CBR backups data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+func testAccCBRV3BackupsDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%s"
+  type             = "server"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = 100
+}
+
+data "sbercloud_cbr_backups" "test" {
+  vault_id = sbercloud_cbr_vault.test.id
+}
+`, rName)
+}
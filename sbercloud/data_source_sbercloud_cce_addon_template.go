@@ -0,0 +1,211 @@
+package sbercloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/addons"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/clusters"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/templates"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func DataSourceCCEAddonTemplateV3() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCCEAddonTemplateV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"spec": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"stable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"support_version": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"bare_metal": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCCEAddonTemplateV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	cceClient, err := config.CceV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+	addonClient, err := config.CceAddonV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE addon client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	cluster, err := clusters.Get(cceClient, clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve CCE cluster %s: %s", clusterID, err)
+	}
+
+	templateList, err := templates.List(addonClient, clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve template list: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	var template *templates.Template
+	for i := range templateList {
+		if templateList[i].Metadata.Name == name {
+			template = &templateList[i]
+			break
+		}
+	}
+	if template == nil {
+		return fmt.Errorf("Your query returned no results, please change your search criteria and try again")
+	}
+
+	// Only keep the template versions supported by the cluster type and version.
+	compatible := make([]addons.Versions, 0, len(template.Spec.Versions))
+	for _, ver := range template.Spec.Versions {
+		if cceAddonSupportsCluster(ver.SupportVersions, cluster.Spec.Type, cluster.Spec.Version) {
+			compatible = append(compatible, ver)
+		}
+	}
+	sort.Slice(compatible, func(i, j int) bool {
+		return compareCCEClusterVersion(compatible[i].Version, compatible[j].Version) > 0
+	})
+	log.Printf("[DEBUG] %d of %d versions of CCE addon template %s are compatible with cluster %s (%s)",
+		len(compatible), len(template.Spec.Versions), name, clusterID, cluster.Spec.Version)
+
+	if len(compatible) == 0 {
+		return fmt.Errorf("No version of the CCE addon template %s is compatible with the cluster version %s",
+			name, cluster.Spec.Version)
+	}
+
+	versions := make([]string, len(compatible))
+	for i, ver := range compatible {
+		versions[i] = ver.Version
+	}
+
+	// The newest compatible version is used unless a version is specified.
+	selected := compatible[0]
+	if v, ok := d.GetOk("version"); ok {
+		found := false
+		for _, ver := range compatible {
+			if ver.Version == v.(string) {
+				selected = ver
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Version %s of the CCE addon template %s is not compatible with the cluster version %s, "+
+				"compatible versions are: %v", v, name, cluster.Spec.Version, versions)
+		}
+	}
+
+	// Return a json string to the user, which contains the contents of the basic and custom fields.
+	specBytes, err := json.Marshal(selected.Input)
+	if err != nil {
+		return fmt.Errorf("Error converting input struct: %s", err)
+	}
+
+	d.SetId(template.Metadata.UID)
+	d.Set("region", region)
+	d.Set("version", selected.Version)
+	d.Set("versions", versions)
+	d.Set("description", template.Spec.Description)
+	d.Set("spec", string(specBytes))
+	d.Set("stable", selected.Stable)
+	if err := setCCEAddonTemplateSupportVersion(d, selected.SupportVersions); err != nil {
+		return fmt.Errorf("Error setting support_version: %s", err)
+	}
+
+	return nil
+}
+
+// cceAddonSupportsCluster reports whether a cluster of the given type and
+// version is listed in supportVersions. The listed cluster versions are
+// patterns such as "v1.19.*".
+func cceAddonSupportsCluster(supportVersions []addons.SupportVersions, clusterType, clusterVersion string) bool {
+	for _, support := range supportVersions {
+		if support.ClusterType != clusterType {
+			continue
+		}
+		for _, pattern := range support.ClusterVersion {
+			if pattern == clusterVersion {
+				return true
+			}
+			if matched, err := regexp.MatchString("^"+pattern+"$", clusterVersion); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func setCCEAddonTemplateSupportVersion(d *schema.ResourceData, supportList []addons.SupportVersions) error {
+	supportVersionMap := map[string]*schema.Set{}
+	for _, supports := range supportList {
+		v := schema.Set{F: schema.HashString}
+		for _, ver := range supports.ClusterVersion {
+			v.Add(ver)
+		}
+		if supports.ClusterType == "VirtualMachine" {
+			supportVersionMap["virtual_machine"] = &v
+		}
+		if supports.ClusterType == "BareMetal" {
+			supportVersionMap["bare_metal"] = &v
+		}
+	}
+	return d.Set("support_version", []map[string]*schema.Set{supportVersionMap})
+}
@@ -0,0 +1,57 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccCCEAddonTemplateV3DataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_cce_addon_template.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonTemplateV3DataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "version"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "version", dataSourceName, "versions.0"),
+					resource.TestMatchResourceAttr(dataSourceName, "spec", regexp.MustCompile(`"basic"`)),
+					resource.TestCheckResourceAttrPair("data.sbercloud_cce_addon_template.pinned", "version",
+						dataSourceName, "versions.0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCCEAddonTemplateV3DataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_cluster" "test" {
+  name                   = "%s"
+  flavor_id              = "cce.s1.small"
+  vpc_id                 = sbercloud_vpc.test.id
+  subnet_id              = sbercloud_vpc_subnet.test.id
+  container_network_type = "overlay_l2"
+}
+
+data "sbercloud_cce_addon_template" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "coredns"
+}
+
+data "sbercloud_cce_addon_template" "pinned" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "coredns"
+  version    = data.sbercloud_cce_addon_template.test.versions[0]
+}
+`, testAccCCEClusterV3_Base(rName), rName)
+}
@@ -0,0 +1,226 @@
+package sbercloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/clusters"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"gopkg.in/yaml.v2"
+)
+
+// cceKubeConfig is the kubeconfig returned by the CCE cluster certificate API.
+// The yaml tags are used to render the kubeconfig for a single endpoint.
+type cceKubeConfig struct {
+	Kind           string                 `json:"kind" yaml:"kind"`
+	ApiVersion     string                 `json:"apiVersion" yaml:"apiVersion"`
+	Preferences    map[string]interface{} `json:"preferences" yaml:"preferences"`
+	Clusters       []cceKubeConfigCluster `json:"clusters" yaml:"clusters"`
+	Users          []cceKubeConfigUser    `json:"users" yaml:"users"`
+	Contexts       []cceKubeConfigContext `json:"contexts" yaml:"contexts"`
+	CurrentContext string                 `json:"current-context" yaml:"current-context"`
+}
+
+type cceKubeConfigCluster struct {
+	Name    string `json:"name" yaml:"name"`
+	Cluster struct {
+		Server                string `json:"server" yaml:"server"`
+		CertAuthorityData     string `json:"certificate-authority-data,omitempty" yaml:"certificate-authority-data,omitempty"`
+		InsecureSkipTLSVerify bool   `json:"insecure-skip-tls-verify,omitempty" yaml:"insecure-skip-tls-verify,omitempty"`
+	} `json:"cluster" yaml:"cluster"`
+}
+
+type cceKubeConfigUser struct {
+	Name string `json:"name" yaml:"name"`
+	User struct {
+		ClientCertData string `json:"client-certificate-data" yaml:"client-certificate-data"`
+		ClientKeyData  string `json:"client-key-data" yaml:"client-key-data"`
+	} `json:"user" yaml:"user"`
+}
+
+type cceKubeConfigContext struct {
+	Name    string `json:"name" yaml:"name"`
+	Context struct {
+		Cluster string `json:"cluster" yaml:"cluster"`
+		User    string `json:"user" yaml:"user"`
+	} `json:"context" yaml:"context"`
+}
+
+// cceKubeConfigContexts lists the kubeconfig contexts for each endpoint type,
+// in order of preference.
+var cceKubeConfigContexts = map[string][]string{
+	"internal": {"internal"},
+	"external": {"externalTLSVerify", "external"},
+}
+
+func DataSourceCCEClusterKubeConfigV3() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCCEClusterKubeConfigV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"duration": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ValidateFunc: validation.Any(
+					validation.IntBetween(1, 1827),
+					validation.IntInSlice([]int{-1}),
+				),
+			},
+			"endpoint_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "internal",
+				ValidateFunc: validation.StringInSlice([]string{
+					"internal", "external",
+				}, false),
+			},
+			"kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_ca_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"insecure": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCCEClusterKubeConfigV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	cceClient, err := config.CceV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	certOpts := map[string]interface{}{
+		"duration": d.Get("duration").(int),
+	}
+
+	var rst golangsdk.Result
+	_, rst.Err = cceClient.Post(cceClient.ServiceURL("clusters", clusterID, "clustercert"), certOpts, &rst.Body,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200, 201},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	if rst.Err != nil {
+		return fmt.Errorf("Error requesting SberCloud CCE cluster certificate: %s", rst.Err)
+	}
+
+	var kubeConfig cceKubeConfig
+	if err := rst.ExtractInto(&kubeConfig); err != nil {
+		return fmt.Errorf("Error extracting SberCloud CCE cluster certificate: %s", err)
+	}
+
+	endpointType := d.Get("endpoint_type").(string)
+	rendered, err := selectCCEKubeConfigContext(&kubeConfig, endpointType)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Using context %s of the SberCloud CCE cluster %s kubeconfig", rendered.CurrentContext, clusterID)
+
+	kubeConfigYAML, err := yaml.Marshal(rendered)
+	if err != nil {
+		return fmt.Errorf("Error rendering kubeconfig: %s", err)
+	}
+
+	cluster, user := rendered.Clusters[0].Cluster, rendered.Users[0].User
+	caCert, err := base64.StdEncoding.DecodeString(cluster.CertAuthorityData)
+	if err != nil {
+		return fmt.Errorf("Error decoding the cluster CA certificate: %s", err)
+	}
+	clientCert, err := base64.StdEncoding.DecodeString(user.ClientCertData)
+	if err != nil {
+		return fmt.Errorf("Error decoding the client certificate: %s", err)
+	}
+	clientKey, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+	if err != nil {
+		return fmt.Errorf("Error decoding the client key: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterID, endpointType))
+	d.Set("region", region)
+	d.Set("kubeconfig", string(kubeConfigYAML))
+	d.Set("host", cluster.Server)
+	d.Set("cluster_ca_certificate", string(caCert))
+	d.Set("client_certificate", string(clientCert))
+	d.Set("client_key", string(clientKey))
+	d.Set("insecure", cluster.InsecureSkipTLSVerify)
+
+	return nil
+}
+
+// selectCCEKubeConfigContext returns a kubeconfig which only holds the context,
+// cluster and user of the given endpoint type.
+func selectCCEKubeConfigContext(kubeConfig *cceKubeConfig, endpointType string) (*cceKubeConfig, error) {
+	for _, name := range cceKubeConfigContexts[endpointType] {
+		for _, ctx := range kubeConfig.Contexts {
+			if ctx.Name != name {
+				continue
+			}
+
+			result := &cceKubeConfig{
+				Kind:           kubeConfig.Kind,
+				ApiVersion:     kubeConfig.ApiVersion,
+				Preferences:    map[string]interface{}{},
+				Contexts:       []cceKubeConfigContext{ctx},
+				CurrentContext: ctx.Name,
+			}
+			for _, c := range kubeConfig.Clusters {
+				if c.Name == ctx.Context.Cluster {
+					result.Clusters = append(result.Clusters, c)
+					break
+				}
+			}
+			for _, u := range kubeConfig.Users {
+				if u.Name == ctx.Context.User {
+					result.Users = append(result.Users, u)
+					break
+				}
+			}
+			if len(result.Clusters) == 0 || len(result.Users) == 0 {
+				return nil, fmt.Errorf("The kubeconfig context %s refers to a missing cluster or user", ctx.Name)
+			}
+			return result, nil
+		}
+	}
+
+	if endpointType == "external" {
+		return nil, fmt.Errorf("The CCE cluster has no external endpoint, bind an EIP to the cluster first")
+	}
+	return nil, fmt.Errorf("The kubeconfig has no %s context", endpointType)
+}
@@ -0,0 +1,55 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccCCEClusterKubeConfigV3DataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	internalName := "data.sbercloud_cce_cluster_kubeconfig.internal"
+	externalName := "data.sbercloud_cce_cluster_kubeconfig.external"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterKubeConfigV3DataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(internalName, "kubeconfig",
+						regexp.MustCompile("current-context: internal")),
+					resource.TestMatchResourceAttr(internalName, "host", regexp.MustCompile(`^https://192\.168\.`)),
+					resource.TestMatchResourceAttr(internalName, "cluster_ca_certificate",
+						regexp.MustCompile("BEGIN CERTIFICATE")),
+					resource.TestMatchResourceAttr(internalName, "client_certificate",
+						regexp.MustCompile("BEGIN CERTIFICATE")),
+					resource.TestCheckResourceAttrSet(internalName, "client_key"),
+					resource.TestMatchResourceAttr(externalName, "kubeconfig",
+						regexp.MustCompile("current-context: external")),
+					resource.TestMatchResourceAttr(externalName, "host", regexp.MustCompile(`^https://`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCCEClusterKubeConfigV3DataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_cce_cluster_kubeconfig" "internal" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  duration   = 7
+}
+
+data "sbercloud_cce_cluster_kubeconfig" "external" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  endpoint_type = "external"
+}
+`, testAccCCEClusterV3_withEip(rName))
+}
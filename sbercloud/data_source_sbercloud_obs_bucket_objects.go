@@ -0,0 +1,173 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// obsListObjectsPageLimit is the maximum page size accepted by the list objects API.
+const obsListObjectsPageLimit = 1000
+
+func DataSourceObsBucketObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceObsBucketObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"marker": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"common_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"next_marker": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceObsBucketObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	obsClient, err := config.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	maxKeys := d.Get("max_keys").(int)
+	input := &obs.ListObjectsInput{}
+	input.Bucket = bucket
+	input.Prefix = d.Get("prefix").(string)
+	input.Delimiter = d.Get("delimiter").(string)
+	input.Marker = d.Get("marker").(string)
+
+	var contents []obs.Content
+	var prefixes []string
+	var nextMarker string
+	for {
+		input.MaxKeys = obsListObjectsPageLimit
+		if remaining := maxKeys - len(contents) - len(prefixes); maxKeys > 0 && remaining < input.MaxKeys {
+			input.MaxKeys = remaining
+		}
+
+		output, err := obsClient.ListObjects(input)
+		if err != nil {
+			return getObsError("Error listing objects of OBS bucket", bucket, err)
+		}
+		contents = append(contents, output.Contents...)
+		prefixes = append(prefixes, output.CommonPrefixes...)
+
+		if !output.IsTruncated {
+			nextMarker = ""
+			break
+		}
+		// the next marker is only returned when a delimiter is specified
+		nextMarker = output.NextMarker
+		if nextMarker == "" && len(output.Contents) > 0 {
+			nextMarker = output.Contents[len(output.Contents)-1].Key
+		}
+		if nextMarker == "" || maxKeys > 0 && len(contents)+len(prefixes) >= maxKeys {
+			break
+		}
+		input.Marker = nextMarker
+	}
+	log.Printf("[DEBUG] Retrieved %d objects and %d common prefixes of OBS bucket %s",
+		len(contents), len(prefixes), bucket)
+
+	keys := make([]string, 0, len(contents))
+	objects := make([]map[string]interface{}, 0, len(contents))
+	for _, content := range contents {
+		class := normalizeStorageClass(string(content.StorageClass))
+		if class == "" {
+			class = "STANDARD"
+		}
+		keys = append(keys, content.Key)
+		objects = append(objects, map[string]interface{}{
+			"key":           content.Key,
+			"size":          int(content.Size),
+			"etag":          strings.Trim(content.ETag, `"`),
+			"storage_class": class,
+			"last_modified": content.LastModified.Format(time.RFC3339),
+		})
+	}
+
+	d.SetId(hashcode.Strings(append([]string{bucket}, keys...)))
+	d.Set("region", region)
+	d.Set("keys", keys)
+	d.Set("common_prefixes", prefixes)
+	d.Set("next_marker", nextMarker)
+	if err := d.Set("objects", objects); err != nil {
+		return fmt.Errorf("Error setting objects of OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
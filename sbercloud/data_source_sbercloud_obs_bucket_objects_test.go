@@ -0,0 +1,63 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccObsBucketObjectsDataSource_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	dataSourceName := "data.sbercloud_obs_bucket_objects.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckOBS(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectsDataSource_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "keys.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "keys.0", "data/readme.txt"),
+					resource.TestCheckResourceAttr(dataSourceName, "objects.0.size", "11"),
+					resource.TestCheckResourceAttr(dataSourceName, "common_prefixes.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "common_prefixes.0", "data/2021/"),
+				),
+			},
+		},
+	})
+}
+
+func testAccObsBucketObjectsDataSource_basic(randInt int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "private"
+}
+
+resource "sbercloud_obs_bucket_object" "readme" {
+  bucket  = sbercloud_obs_bucket.bucket.bucket
+  key     = "data/readme.txt"
+  content = "readme text"
+}
+
+resource "sbercloud_obs_bucket_object" "log" {
+  bucket  = sbercloud_obs_bucket.bucket.bucket
+  key     = "data/2021/07/01.log"
+  content = "log text"
+}
+
+data "sbercloud_obs_bucket_objects" "test" {
+  bucket    = sbercloud_obs_bucket.bucket.bucket
+  prefix    = "data/"
+  delimiter = "/"
+
+  depends_on = [
+    sbercloud_obs_bucket_object.readme,
+    sbercloud_obs_bucket_object.log,
+  ]
+}
+`, randInt)
+}
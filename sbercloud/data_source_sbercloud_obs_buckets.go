@@ -0,0 +1,138 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func DataSourceObsBuckets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceObsBucketsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"buckets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getObsBucketTags(obsClient *obs.ObsClient, bucket string) (map[string]string, error) {
+	tags := make(map[string]string)
+	output, err := obsClient.GetBucketTagging(bucket)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.Code == "NoSuchTagSet" {
+			return tags, nil
+		}
+		return nil, getObsError("Error getting tags of OBS bucket", bucket, err)
+	}
+
+	for _, tag := range output.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+func dataSourceObsBucketsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	obsClient, err := config.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	output, err := obsClient.ListBuckets(&obs.ListBucketsInput{QueryLocation: true})
+	if err != nil {
+		return fmt.Errorf("Error listing OBS buckets: %s", err)
+	}
+
+	// the buckets of all regions are listed, and only filtered by region if
+	// it is specified
+	filterRegion := d.Get("region").(string)
+	name := d.Get("bucket").(string)
+	// the bucket details are queried from the endpoint of the bucket region
+	clients := map[string]*obs.ObsClient{region: obsClient}
+	ids := make([]string, 0, len(output.Buckets))
+	buckets := make([]map[string]interface{}, 0, len(output.Buckets))
+	for _, bucket := range output.Buckets {
+		if filterRegion != "" && bucket.Location != filterRegion || name != "" && bucket.Name != name {
+			continue
+		}
+
+		client, ok := clients[bucket.Location]
+		if !ok {
+			client, err = config.ObjectStorageClient(bucket.Location)
+			if err != nil {
+				return fmt.Errorf("Error creating SberCloud OBS client of region %s: %s", bucket.Location, err)
+			}
+			clients[bucket.Location] = client
+		}
+
+		policy, err := client.GetBucketStoragePolicy(bucket.Name)
+		if err != nil {
+			return getObsError("Error getting storage class of OBS bucket", bucket.Name, err)
+		}
+		tags, err := getObsBucketTags(client, bucket.Name)
+		if err != nil {
+			return err
+		}
+
+		ids = append(ids, bucket.Name)
+		buckets = append(buckets, map[string]interface{}{
+			"bucket":        bucket.Name,
+			"region":        bucket.Location,
+			"storage_class": normalizeStorageClass(policy.StorageClass),
+			"created_at":    bucket.CreationDate.Format(time.RFC3339),
+			"tags":          tags,
+		})
+	}
+	log.Printf("[DEBUG] Retrieved %d OBS buckets", len(buckets))
+
+	d.SetId(hashcode.Strings(ids))
+	if err := d.Set("buckets", buckets); err != nil {
+		return fmt.Errorf("Error setting OBS buckets: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccObsBucketsDataSource_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	dataSourceName := "data.sbercloud_obs_buckets.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckOBS(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketsDataSource_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "buckets.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "buckets.0.region", SBC_REGION_NAME),
+					resource.TestCheckResourceAttr(dataSourceName, "buckets.0.storage_class", "WARM"),
+					resource.TestCheckResourceAttr(dataSourceName, "buckets.0.tags.team", "data"),
+				),
+			},
+		},
+	})
+}
+
+func testAccObsBucketsDataSource_basic(randInt int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket        = "tf-test-bucket-%d"
+  acl           = "private"
+  storage_class = "WARM"
+
+  tags = {
+    team = "data"
+  }
+}
+
+data "sbercloud_obs_buckets" "test" {
+  bucket = sbercloud_obs_bucket.bucket.bucket
+}
+`, randInt)
+}
@@ -0,0 +1,203 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// vpcFlowLogsPageLimit is the page size of listing the flow logs.
+const vpcFlowLogsPageLimit = 100
+
+func DataSourceVpcFlowLogsV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVpcFlowLogsV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"port", "network", "vpc",
+				}, false),
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"traffic_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"all", "accept", "reject",
+				}, false),
+			},
+			"log_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"log_topic_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ACTIVE", "DOWN", "ERROR",
+				}, false),
+			},
+			"resource_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"flow_logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"traffic_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"log_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"log_topic_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVpcFlowLogsV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	query := url.Values{}
+	for _, filter := range []string{
+		"name", "resource_type", "resource_id", "traffic_type", "log_group_id", "log_topic_id", "status",
+	} {
+		if v, ok := d.GetOk(filter); ok {
+			query.Set(filter, v.(string))
+		}
+	}
+	query.Set("limit", fmt.Sprintf("%d", vpcFlowLogsPageLimit))
+
+	allFlowLogs := make([]vpcFlowLog, 0)
+	for {
+		var rst golangsdk.Result
+		_, rst.Err = client.Get(vpcFlowLogURL(client)+"?"+query.Encode(), &rst.Body, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if rst.Err != nil {
+			return fmt.Errorf("Error querying SberCloud VPC flow logs: %s", rst.Err)
+		}
+
+		var page struct {
+			FlowLogs []vpcFlowLog `json:"flow_logs"`
+		}
+		if err := rst.ExtractInto(&page); err != nil {
+			return fmt.Errorf("Error extracting SberCloud VPC flow logs: %s", err)
+		}
+
+		allFlowLogs = append(allFlowLogs, page.FlowLogs...)
+		if len(page.FlowLogs) < vpcFlowLogsPageLimit {
+			break
+		}
+		query.Set("marker", page.FlowLogs[len(page.FlowLogs)-1].ID)
+	}
+	log.Printf("[DEBUG] Retrieved %d SberCloud VPC flow logs", len(allFlowLogs))
+
+	ids := make([]string, 0, len(allFlowLogs))
+	resourceIDs := make([]string, 0, len(allFlowLogs))
+	seen := make(map[string]bool)
+	flowLogs := make([]map[string]interface{}, 0, len(allFlowLogs))
+	for _, fl := range allFlowLogs {
+		ids = append(ids, fl.ID)
+		if !seen[fl.ResourceID] {
+			seen[fl.ResourceID] = true
+			resourceIDs = append(resourceIDs, fl.ResourceID)
+		}
+		flowLogs = append(flowLogs, map[string]interface{}{
+			"id":            fl.ID,
+			"name":          fl.Name,
+			"description":   fl.Description,
+			"resource_type": fl.ResourceType,
+			"resource_id":   fl.ResourceID,
+			"traffic_type":  fl.TrafficType,
+			"log_group_id":  fl.LogGroupID,
+			"log_topic_id":  fl.LogTopicID,
+			"enabled":       fl.AdminState != nil && *fl.AdminState,
+			"status":        fl.Status,
+			"created_at":    fl.CreatedAt,
+		})
+	}
+
+	d.SetId(hashcode.Strings(ids))
+	d.Set("region", region)
+	d.Set("resource_ids", resourceIDs)
+	if err := d.Set("flow_logs", flowLogs); err != nil {
+		return fmt.Errorf("Error setting VPC flow logs: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccVpcFlowLogsDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_vpc_flow_logs.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckLTS(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLogsDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "flow_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "flow_logs.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "flow_logs.0.enabled", "true"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resource_ids.0", "sbercloud_vpc.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcFlowLogsDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_vpc_flow_logs" "test" {
+  resource_id = sbercloud_vpc_flow_log.test.resource_id
+}
+`, testAccVpcFlowLog_basic(rName, true))
+}
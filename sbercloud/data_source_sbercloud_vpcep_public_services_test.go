@@ -0,0 +1,32 @@
+package sbercloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccVPCEPPublicServicesDataSource_basic(t *testing.T) {
+	resourceName := "data.sbercloud_vpcep_public_services.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCEPPublicServicesDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "services.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "services.0.service_name"),
+					resource.TestCheckResourceAttr(resourceName, "services.0.service_type", "interface"),
+				),
+			},
+		},
+	})
+}
+
+const testAccVPCEPPublicServicesDataSource_basic = `
+data "sbercloud_vpcep_public_services" "test" {
+  service_name = "dns"
+}
+`
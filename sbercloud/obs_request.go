@@ -0,0 +1,123 @@
+package sbercloud
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func obsEndpoint(config *config.Config, region string) string {
+	if endpoint, ok := config.Endpoints["obs"]; ok {
+		return endpoint
+	}
+	return fmt.Sprintf("https://obs.%s.%s/", region, config.Cloud)
+}
+
+// obsBucketRequest sends a bucket request signed with the OBS signature and
+// decodes the XML response into result. It serves the bucket sub-resources
+// which the OBS SDK does not support: the SDK leaves unknown sub-resources out
+// of the string to sign, so such requests are rejected by OBS.
+func obsBucketRequest(config *config.Config, region, method, bucket, subResource string,
+	headers map[string]string, body []byte, result interface{}) error {
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return fmt.Errorf("missing credentials for OBS, need access_key and secret_key values for provider")
+	}
+
+	u, err := url.Parse(obsEndpoint(config, region))
+	if err != nil {
+		return fmt.Errorf("Error parsing OBS endpoint: %s", err)
+	}
+	// OBS serves buckets on the virtual host, unless the endpoint is an IP address
+	if net.ParseIP(u.Hostname()) != nil {
+		u.Path = "/" + bucket + "/"
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path = "/"
+	}
+	u.RawQuery = subResource
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/xml")
+		req.Header.Set("Content-MD5", obs.Base64Md5(body))
+	}
+	if config.SecurityToken != "" {
+		req.Header.Set("x-obs-security-token", config.SecurityToken)
+	}
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+
+	resource := "/" + bucket + "/"
+	if subResource != "" {
+		resource += "?" + subResource
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("OBS %s:%s", config.AccessKey,
+		obsSignature(config.SecretKey, req, resource)))
+
+	log.Printf("[DEBUG] OBS request: %s %s", method, u.String())
+	resp, err := config.HwClient.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		obsError := obs.ObsError{Status: resp.Status}
+		obsError.StatusCode = resp.StatusCode
+		obsError.RequestId = resp.Header.Get("x-obs-request-id")
+		if len(data) > 0 {
+			xml.Unmarshal(data, &obsError)
+		}
+		return obsError
+	}
+
+	if result != nil && len(data) > 0 {
+		return xml.Unmarshal(data, result)
+	}
+	return nil
+}
+
+// obsSignature signs the request as described in "Authentication of Signature
+// in a Header" of the OBS API reference.
+func obsSignature(secretKey string, req *http.Request, resource string) string {
+	var obsHeaders []string
+	for k, v := range req.Header {
+		key := strings.ToLower(k)
+		if strings.HasPrefix(key, "x-obs-") {
+			obsHeaders = append(obsHeaders, key+":"+strings.TrimSpace(strings.Join(v, ",")))
+		}
+	}
+	sort.Strings(obsHeaders)
+
+	var stringToSign strings.Builder
+	stringToSign.WriteString(req.Method + "\n")
+	stringToSign.WriteString(req.Header.Get("Content-MD5") + "\n")
+	stringToSign.WriteString(req.Header.Get("Content-Type") + "\n")
+	stringToSign.WriteString(req.Header.Get("Date") + "\n")
+	for _, h := range obsHeaders {
+		stringToSign.WriteString(h + "\n")
+	}
+	stringToSign.WriteString(resource)
+
+	return obs.Base64Encode(obs.HmacSha1([]byte(secretKey), []byte(stringToSign.String())))
+}
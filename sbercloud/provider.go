@@ -108,104 +108,134 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"sbercloud_availability_zones":  huaweicloud.DataSourceAvailabilityZones(),
-			"sbercloud_cce_cluster":         huaweicloud.DataSourceCCEClusterV3(),
-			"sbercloud_cce_node":            huaweicloud.DataSourceCCENodeV3(),
-			"sbercloud_cce_node_pool":       huaweicloud.DataSourceCCENodePoolV3(),
-			"sbercloud_compute_flavors":     huaweicloud.DataSourceEcsFlavors(),
-			"sbercloud_dcs_az":              huaweicloud.DataSourceDcsAZV1(),
-			"sbercloud_dcs_maintainwindow":  huaweicloud.DataSourceDcsMaintainWindowV1(),
-			"sbercloud_dcs_product":         huaweicloud.DataSourceDcsProductV1(),
-			"sbercloud_dds_flavors":         huaweicloud.DataSourceDDSFlavorV3(),
-			"sbercloud_dis_partition":       huaweicloud.DataSourceDisPartitionV2(),
-			"sbercloud_dms_az":              huaweicloud.DataSourceDmsAZV1(),
-			"sbercloud_dms_product":         huaweicloud.DataSourceDmsProductV1(),
-			"sbercloud_dms_maintainwindow":  huaweicloud.DataSourceDmsMaintainWindowV1(),
-			"sbercloud_identity_role":       huaweicloud.DataSourceIdentityRoleV3(),
-			"sbercloud_images_image":        huaweicloud.DataSourceImagesImageV2(),
-			"sbercloud_kms_key":             huaweicloud.DataSourceKmsKeyV1(),
-			"sbercloud_kms_data_key":        huaweicloud.DataSourceKmsDataKeyV1(),
-			"sbercloud_nat_gateway":         huaweicloud.DataSourceNatGatewayV2(),
-			"sbercloud_networking_port":     huaweicloud.DataSourceNetworkingPortV2(),
-			"sbercloud_networking_secgroup": huaweicloud.DataSourceNetworkingSecGroupV2(),
-			"sbercloud_obs_bucket_object":   huaweicloud.DataSourceObsBucketObject(),
-			"sbercloud_rds_flavors":         huaweicloud.DataSourceRdsFlavorV3(),
-			"sbercloud_sfs_file_system":     huaweicloud.DataSourceSFSFileSystemV2(),
-			"sbercloud_vpc":                 huaweicloud.DataSourceVirtualPrivateCloudVpcV1(),
-			"sbercloud_vpc_bandwidth":       huaweicloud.DataSourceBandWidth(),
-			"sbercloud_vpc_subnet":          huaweicloud.DataSourceVpcSubnetV1(),
-			"sbercloud_vpc_subnet_ids":      huaweicloud.DataSourceVpcSubnetIdsV1(),
-			"sbercloud_vpc_route":           huaweicloud.DataSourceVPCRouteV2(),
+			"sbercloud_availability_zones":     huaweicloud.DataSourceAvailabilityZones(),
+			"sbercloud_cbr_backups":            DataSourceCBRBackupsV3(),
+			"sbercloud_cce_addon_template":     DataSourceCCEAddonTemplateV3(),
+			"sbercloud_cce_cluster":            huaweicloud.DataSourceCCEClusterV3(),
+			"sbercloud_cce_cluster_kubeconfig": DataSourceCCEClusterKubeConfigV3(),
+			"sbercloud_cce_node":               huaweicloud.DataSourceCCENodeV3(),
+			"sbercloud_cce_node_pool":          huaweicloud.DataSourceCCENodePoolV3(),
+			"sbercloud_compute_flavors":        huaweicloud.DataSourceEcsFlavors(),
+			"sbercloud_dcs_az":                 huaweicloud.DataSourceDcsAZV1(),
+			"sbercloud_dcs_maintainwindow":     huaweicloud.DataSourceDcsMaintainWindowV1(),
+			"sbercloud_dcs_product":            huaweicloud.DataSourceDcsProductV1(),
+			"sbercloud_dds_flavors":            huaweicloud.DataSourceDDSFlavorV3(),
+			"sbercloud_dis_partition":          huaweicloud.DataSourceDisPartitionV2(),
+			"sbercloud_dms_az":                 huaweicloud.DataSourceDmsAZV1(),
+			"sbercloud_dms_product":            huaweicloud.DataSourceDmsProductV1(),
+			"sbercloud_dms_maintainwindow":     huaweicloud.DataSourceDmsMaintainWindowV1(),
+			"sbercloud_identity_role":          huaweicloud.DataSourceIdentityRoleV3(),
+			"sbercloud_images_image":           huaweicloud.DataSourceImagesImageV2(),
+			"sbercloud_kms_key":                huaweicloud.DataSourceKmsKeyV1(),
+			"sbercloud_kms_data_key":           huaweicloud.DataSourceKmsDataKeyV1(),
+			"sbercloud_nat_gateway":            huaweicloud.DataSourceNatGatewayV2(),
+			"sbercloud_networking_port":        huaweicloud.DataSourceNetworkingPortV2(),
+			"sbercloud_networking_secgroup":    huaweicloud.DataSourceNetworkingSecGroupV2(),
+			"sbercloud_obs_bucket_object":      huaweicloud.DataSourceObsBucketObject(),
+			"sbercloud_obs_bucket_objects":     DataSourceObsBucketObjects(),
+			"sbercloud_obs_buckets":            DataSourceObsBuckets(),
+			"sbercloud_rds_flavors":            huaweicloud.DataSourceRdsFlavorV3(),
+			"sbercloud_sfs_file_system":        huaweicloud.DataSourceSFSFileSystemV2(),
+			"sbercloud_vpc":                    huaweicloud.DataSourceVirtualPrivateCloudVpcV1(),
+			"sbercloud_vpc_bandwidth":          huaweicloud.DataSourceBandWidth(),
+			"sbercloud_vpc_subnet":             huaweicloud.DataSourceVpcSubnetV1(),
+			"sbercloud_vpc_subnet_ids":         huaweicloud.DataSourceVpcSubnetIdsV1(),
+			"sbercloud_vpc_route":              huaweicloud.DataSourceVPCRouteV2(),
+			"sbercloud_vpc_flow_logs":          DataSourceVpcFlowLogsV1(),
+			"sbercloud_vpcep_public_services":  huaweicloud.DataSourceVPCEPPublicServices(),
 			// Legacy
 			"sbercloud_identity_role_v3": huaweicloud.DataSourceIdentityRoleV3(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"sbercloud_api_gateway_api":           huaweicloud.ResourceAPIGatewayAPI(),
-			"sbercloud_api_gateway_group":         huaweicloud.ResourceAPIGatewayGroup(),
-			"sbercloud_as_configuration":          huaweicloud.ResourceASConfiguration(),
-			"sbercloud_as_group":                  huaweicloud.ResourceASGroup(),
-			"sbercloud_as_policy":                 huaweicloud.ResourceASPolicy(),
-			"sbercloud_cce_cluster":               huaweicloud.ResourceCCEClusterV3(),
-			"sbercloud_cce_node":                  huaweicloud.ResourceCCENodeV3(),
-			"sbercloud_cce_node_pool":             huaweicloud.ResourceCCENodePool(),
-			"sbercloud_compute_instance":          huaweicloud.ResourceComputeInstanceV2(),
-			"sbercloud_compute_interface_attach":  huaweicloud.ResourceComputeInterfaceAttachV2(),
-			"sbercloud_compute_keypair":           huaweicloud.ResourceComputeKeypairV2(),
-			"sbercloud_compute_servergroup":       huaweicloud.ResourceComputeServerGroupV2(),
-			"sbercloud_compute_eip_associate":     huaweicloud.ResourceComputeFloatingIPAssociateV2(),
-			"sbercloud_compute_volume_attach":     huaweicloud.ResourceComputeVolumeAttachV2(),
-			"sbercloud_dcs_instance":              huaweicloud.ResourceDcsInstanceV1(),
-			"sbercloud_dds_instance":              huaweicloud.ResourceDdsInstanceV3(),
-			"sbercloud_dis_stream":                huaweicloud.ResourceDisStreamV2(),
-			"sbercloud_dms_instance":              ResourceDmsInstancesV1(),
-			"sbercloud_dli_queue":                 huaweicloud.ResourceDliQueueV1(),
-			"sbercloud_dns_recordset":             huaweicloud.ResourceDNSRecordSetV2(),
-			"sbercloud_dns_zone":                  huaweicloud.ResourceDNSZoneV2(),
-			"sbercloud_evs_snapshot":              huaweicloud.ResourceEvsSnapshotV2(),
-			"sbercloud_evs_volume":                huaweicloud.ResourceEvsStorageVolumeV3(),
-			"sbercloud_fgs_function":              huaweicloud.ResourceFgsFunctionV2(),
-			"sbercloud_identity_agency":           huaweicloud.ResourceIAMAgencyV3(),
-			"sbercloud_identity_role_assignment":  huaweicloud.ResourceIdentityRoleAssignmentV3(),
-			"sbercloud_identity_user":             huaweicloud.ResourceIdentityUserV3(),
-			"sbercloud_identity_group":            huaweicloud.ResourceIdentityGroupV3(),
-			"sbercloud_identity_group_membership": huaweicloud.ResourceIdentityGroupMembershipV3(),
-			"sbercloud_images_image":              huaweicloud.ResourceImsImage(),
-			"sbercloud_kms_key":                   huaweicloud.ResourceKmsKeyV1(),
-			"sbercloud_lb_certificate":            huaweicloud.ResourceCertificateV2(),
-			"sbercloud_lb_l7policy":               huaweicloud.ResourceL7PolicyV2(),
-			"sbercloud_lb_l7rule":                 huaweicloud.ResourceL7RuleV2(),
-			"sbercloud_lb_listener":               huaweicloud.ResourceListenerV2(),
-			"sbercloud_lb_loadbalancer":           huaweicloud.ResourceLoadBalancerV2(),
-			"sbercloud_lb_member":                 huaweicloud.ResourceMemberV2(),
-			"sbercloud_lb_monitor":                huaweicloud.ResourceMonitorV2(),
-			"sbercloud_lb_pool":                   huaweicloud.ResourcePoolV2(),
-			"sbercloud_lb_whitelist":              huaweicloud.ResourceWhitelistV2(),
-			"sbercloud_nat_dnat_rule":             huaweicloud.ResourceNatDnatRuleV2(),
-			"sbercloud_nat_gateway":               huaweicloud.ResourceNatGatewayV2(),
-			"sbercloud_nat_snat_rule":             huaweicloud.ResourceNatSnatRuleV2(),
-			"sbercloud_network_acl":               huaweicloud.ResourceNetworkACL(),
-			"sbercloud_network_acl_rule":          huaweicloud.ResourceNetworkACLRule(),
-			"sbercloud_networking_eip_associate":  huaweicloud.ResourceNetworkingFloatingIPAssociateV2(),
-			"sbercloud_networking_secgroup":       huaweicloud.ResourceNetworkingSecGroupV2(),
-			"sbercloud_networking_secgroup_rule":  huaweicloud.ResourceNetworkingSecGroupRuleV2(),
-			"sbercloud_obs_bucket":                huaweicloud.ResourceObsBucket(),
-			"sbercloud_obs_bucket_object":         huaweicloud.ResourceObsBucketObject(),
-			"sbercloud_obs_bucket_policy":         huaweicloud.ResourceObsBucketPolicy(),
-			"sbercloud_rds_instance":              ResourceRdsInstanceV3(),
-			"sbercloud_rds_parametergroup":        huaweicloud.ResourceRdsConfigurationV3(),
-			"sbercloud_rds_read_replica_instance": huaweicloud.ResourceRdsReadReplicaInstance(),
-			"sbercloud_sfs_access_rule":           huaweicloud.ResourceSFSAccessRuleV2(),
-			"sbercloud_sfs_file_system":           huaweicloud.ResourceSFSFileSystemV2(),
-			"sbercloud_sfs_turbo":                 huaweicloud.ResourceSFSTurbo(),
-			"sbercloud_smn_subscription":          huaweicloud.ResourceSubscription(),
-			"sbercloud_smn_topic":                 huaweicloud.ResourceTopic(),
-			"sbercloud_vpc":                       huaweicloud.ResourceVirtualPrivateCloudV1(),
-			"sbercloud_vpc_bandwidth":             huaweicloud.ResourceVpcBandWidthV2(),
-			"sbercloud_vpc_eip":                   huaweicloud.ResourceVpcEIPV1(),
-			"sbercloud_vpc_route":                 huaweicloud.ResourceVPCRouteV2(),
-			"sbercloud_vpc_peering_connection":    huaweicloud.ResourceVpcPeeringConnectionV2(),
-			"sbercloud_vpc_subnet":                huaweicloud.ResourceVpcSubnetV1(),
+			"sbercloud_api_gateway_api":                 huaweicloud.ResourceAPIGatewayAPI(),
+			"sbercloud_api_gateway_group":               huaweicloud.ResourceAPIGatewayGroup(),
+			"sbercloud_as_configuration":                huaweicloud.ResourceASConfiguration(),
+			"sbercloud_as_group":                        huaweicloud.ResourceASGroup(),
+			"sbercloud_as_policy":                       huaweicloud.ResourceASPolicy(),
+			"sbercloud_cbr_policy":                      ResourceCBRPolicyV3(),
+			"sbercloud_cbr_vault":                       ResourceCBRVaultV3(),
+			"sbercloud_cce_addon":                       ResourceCCEAddonV3(),
+			"sbercloud_cce_cluster":                     ResourceCCEClusterV3(),
+			"sbercloud_cce_namespace":                   ResourceCCENamespaceV1(),
+			"sbercloud_cce_node":                        huaweicloud.ResourceCCENodeV3(),
+			"sbercloud_cce_node_pool":                   ResourceCCENodePool(),
+			"sbercloud_cce_permission":                  ResourceCCEPermissionV3(),
+			"sbercloud_compute_instance":                ResourceComputeInstanceV2(),
+			"sbercloud_compute_interface_attach":        huaweicloud.ResourceComputeInterfaceAttachV2(),
+			"sbercloud_compute_keypair":                 huaweicloud.ResourceComputeKeypairV2(),
+			"sbercloud_compute_servergroup":             huaweicloud.ResourceComputeServerGroupV2(),
+			"sbercloud_compute_eip_associate":           huaweicloud.ResourceComputeFloatingIPAssociateV2(),
+			"sbercloud_compute_volume_attach":           huaweicloud.ResourceComputeVolumeAttachV2(),
+			"sbercloud_dcs_instance":                    huaweicloud.ResourceDcsInstanceV1(),
+			"sbercloud_dds_instance":                    huaweicloud.ResourceDdsInstanceV3(),
+			"sbercloud_dis_stream":                      huaweicloud.ResourceDisStreamV2(),
+			"sbercloud_dms_instance":                    ResourceDmsInstancesV1(),
+			"sbercloud_dli_queue":                       huaweicloud.ResourceDliQueueV1(),
+			"sbercloud_dns_recordset":                   huaweicloud.ResourceDNSRecordSetV2(),
+			"sbercloud_dns_zone":                        huaweicloud.ResourceDNSZoneV2(),
+			"sbercloud_evs_snapshot":                    huaweicloud.ResourceEvsSnapshotV2(),
+			"sbercloud_evs_volume":                      huaweicloud.ResourceEvsStorageVolumeV3(),
+			"sbercloud_fgs_function":                    huaweicloud.ResourceFgsFunctionV2(),
+			"sbercloud_identity_agency":                 huaweicloud.ResourceIAMAgencyV3(),
+			"sbercloud_identity_role_assignment":        huaweicloud.ResourceIdentityRoleAssignmentV3(),
+			"sbercloud_identity_user":                   huaweicloud.ResourceIdentityUserV3(),
+			"sbercloud_identity_group":                  huaweicloud.ResourceIdentityGroupV3(),
+			"sbercloud_identity_group_membership":       huaweicloud.ResourceIdentityGroupMembershipV3(),
+			"sbercloud_images_image":                    huaweicloud.ResourceImsImage(),
+			"sbercloud_kms_key":                         huaweicloud.ResourceKmsKeyV1(),
+			"sbercloud_lb_certificate":                  huaweicloud.ResourceCertificateV2(),
+			"sbercloud_lb_l7policy":                     huaweicloud.ResourceL7PolicyV2(),
+			"sbercloud_lb_l7rule":                       huaweicloud.ResourceL7RuleV2(),
+			"sbercloud_lb_listener":                     huaweicloud.ResourceListenerV2(),
+			"sbercloud_lb_loadbalancer":                 huaweicloud.ResourceLoadBalancerV2(),
+			"sbercloud_lb_member":                       huaweicloud.ResourceMemberV2(),
+			"sbercloud_lb_monitor":                      huaweicloud.ResourceMonitorV2(),
+			"sbercloud_lb_pool":                         huaweicloud.ResourcePoolV2(),
+			"sbercloud_lb_whitelist":                    huaweicloud.ResourceWhitelistV2(),
+			"sbercloud_nat_dnat_rule":                   huaweicloud.ResourceNatDnatRuleV2(),
+			"sbercloud_nat_gateway":                     huaweicloud.ResourceNatGatewayV2(),
+			"sbercloud_nat_snat_rule":                   huaweicloud.ResourceNatSnatRuleV2(),
+			"sbercloud_network_acl":                     ResourceNetworkACL(),
+			"sbercloud_network_acl_rule":                ResourceNetworkACLRule(),
+			"sbercloud_networking_eip_associate":        huaweicloud.ResourceNetworkingFloatingIPAssociateV2(),
+			"sbercloud_networking_port":                 ResourceNetworkingPortV2(),
+			"sbercloud_networking_secgroup":             ResourceNetworkingSecGroupV2(),
+			"sbercloud_networking_secgroup_rule":        ResourceNetworkingSecGroupRuleV2(),
+			"sbercloud_networking_vip":                  ResourceNetworkingVIPV2(),
+			"sbercloud_networking_vip_associate":        ResourceNetworkingVIPAssociateV2(),
+			"sbercloud_obs_bucket":                      ResourceObsBucket(),
+			"sbercloud_obs_bucket_object":               huaweicloud.ResourceObsBucketObject(),
+			"sbercloud_obs_bucket_objects":              ResourceObsBucketObjects(),
+			"sbercloud_obs_bucket_notification":         ResourceObsBucketNotification(),
+			"sbercloud_obs_bucket_policy":               huaweicloud.ResourceObsBucketPolicy(),
+			"sbercloud_obs_bucket_replication":          ResourceObsBucketReplication(),
+			"sbercloud_rds_instance":                    ResourceRdsInstanceV3(),
+			"sbercloud_rds_parametergroup":              huaweicloud.ResourceRdsConfigurationV3(),
+			"sbercloud_rds_read_replica_instance":       huaweicloud.ResourceRdsReadReplicaInstance(),
+			"sbercloud_sfs_access_rule":                 huaweicloud.ResourceSFSAccessRuleV2(),
+			"sbercloud_sfs_file_system":                 huaweicloud.ResourceSFSFileSystemV2(),
+			"sbercloud_sfs_turbo":                       huaweicloud.ResourceSFSTurbo(),
+			"sbercloud_smn_subscription":                huaweicloud.ResourceSubscription(),
+			"sbercloud_smn_topic":                       huaweicloud.ResourceTopic(),
+			"sbercloud_vpc":                             ResourceVirtualPrivateCloudV1(),
+			"sbercloud_vpc_address_group":               ResourceVpcAddressGroup(),
+			"sbercloud_vpc_bandwidth":                   ResourceVpcBandWidthV2(),
+			"sbercloud_vpc_bandwidth_associate":         ResourceVpcBandWidthAssociate(),
+			"sbercloud_vpc_eip":                         ResourceVpcEIPV1(),
+			"sbercloud_vpc_flow_log":                    ResourceVpcFlowLogV1(),
+			"sbercloud_vpc_route":                       huaweicloud.ResourceVPCRouteV2(),
+			"sbercloud_vpc_peering_connection":          ResourceVpcPeeringConnectionV2(),
+			"sbercloud_vpc_peering_connection_accepter": ResourceVpcPeeringConnectionAccepterV2(),
+			"sbercloud_vpc_subnet":                      ResourceVpcSubnetV1(),
+			"sbercloud_vpcep_approval":                  huaweicloud.ResourceVPCEndpointApproval(),
+			"sbercloud_vpcep_endpoint":                  huaweicloud.ResourceVPCEndpoint(),
+			"sbercloud_vpcep_service":                   huaweicloud.ResourceVPCEndpointService(),
+			"sbercloud_vpnaas_endpoint_group":           huaweicloud.ResourceVpnEndpointGroupV2(),
+			"sbercloud_vpnaas_ike_policy":               huaweicloud.ResourceVpnIKEPolicyV2(),
+			"sbercloud_vpnaas_ipsec_policy":             huaweicloud.ResourceVpnIPSecPolicyV2(),
+			"sbercloud_vpnaas_service":                  huaweicloud.ResourceVpnServiceV2(),
+			"sbercloud_vpnaas_site_connection":          ResourceVpnSiteConnectionV2(),
 			// Legacy
 			"sbercloud_identity_role_assignment_v3":  huaweicloud.ResourceIdentityRoleAssignmentV3(),
 			"sbercloud_identity_user_v3":             huaweicloud.ResourceIdentityUserV3(),
@@ -15,12 +15,20 @@ var (
 	SBC_ACCESS_KEY                 = os.Getenv("SBC_ACCESS_KEY")
 	SBC_ACCOUNT_NAME               = os.Getenv("SBC_ACCOUNT_NAME")
 	SBC_ADMIN                      = os.Getenv("SBC_ADMIN")
+	SBC_DEST_PROJECT_ID            = os.Getenv("SBC_DEST_PROJECT_ID")
+	SBC_DEST_REGION                = os.Getenv("SBC_DEST_REGION")
 	SBC_DOMAIN_ID                  = os.Getenv("SBC_DOMAIN_ID")
 	SBC_DOMAIN_NAME                = os.Getenv("SBC_DOMAIN_NAME")
 	SBC_ENTERPRISE_PROJECT_ID_TEST = os.Getenv("SBC_ENTERPRISE_PROJECT_ID_TEST")
+	SBC_LTS_GROUP_ID               = os.Getenv("SBC_LTS_GROUP_ID")
+	SBC_LTS_TOPIC_ID               = os.Getenv("SBC_LTS_TOPIC_ID")
+	SBC_PEER_ACCESS_KEY            = os.Getenv("SBC_PEER_ACCESS_KEY")
+	SBC_PEER_PROJECT_ID            = os.Getenv("SBC_PEER_PROJECT_ID")
+	SBC_PEER_SECRET_KEY            = os.Getenv("SBC_PEER_SECRET_KEY")
 	SBC_PROJECT_ID                 = os.Getenv("SBC_PROJECT_ID")
 	SBC_REGION_NAME                = os.Getenv("SBC_REGION_NAME")
 	SBC_SECRET_KEY                 = os.Getenv("SBC_SECRET_KEY")
+	SBC_SMN_TOPIC_URN              = os.Getenv("SBC_SMN_TOPIC_URN")
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -55,12 +63,52 @@ func testAccPreCheckEpsID(t *testing.T) {
 	}
 }
 
+func testAccPreCheckProjectID(t *testing.T) {
+	if SBC_PROJECT_ID == "" {
+		t.Skip("SBC_PROJECT_ID must be set for acceptance tests")
+	}
+}
+
+func testAccPreCheckCBRReplication(t *testing.T) {
+	if SBC_DEST_REGION == "" || SBC_DEST_PROJECT_ID == "" {
+		t.Skip("SBC_DEST_REGION and SBC_DEST_PROJECT_ID must be set for CBR replication acceptance tests")
+	}
+}
+
 func testAccPreCheckOBS(t *testing.T) {
 	if SBC_ACCESS_KEY == "" || SBC_SECRET_KEY == "" {
 		t.Skip("SBC_ACCESS_KEY and SBC_SECRET_KEY must be set for OBS acceptance tests")
 	}
 }
 
+func testAccPreCheckOBSReplication(t *testing.T) {
+	testAccPreCheckOBS(t)
+	if SBC_DEST_REGION == "" {
+		t.Skip("SBC_DEST_REGION must be set for OBS replication acceptance tests")
+	}
+}
+
+func testAccPreCheckOBSNotification(t *testing.T) {
+	testAccPreCheckOBS(t)
+	if SBC_SMN_TOPIC_URN == "" {
+		t.Skip("SBC_SMN_TOPIC_URN, a topic which allows OBS to publish, must be set for OBS notification acceptance tests")
+	}
+}
+
+func testAccPreCheckLTS(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+	if SBC_LTS_GROUP_ID == "" || SBC_LTS_TOPIC_ID == "" {
+		t.Skip("SBC_LTS_GROUP_ID and SBC_LTS_TOPIC_ID must be set for LTS acceptance tests")
+	}
+}
+
+func testAccPreCheckPeerTenant(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+	if SBC_PEER_ACCESS_KEY == "" || SBC_PEER_SECRET_KEY == "" || SBC_PEER_PROJECT_ID == "" {
+		t.Skip("SBC_PEER_ACCESS_KEY, SBC_PEER_SECRET_KEY and SBC_PEER_PROJECT_ID must be set for cross-tenant acceptance tests")
+	}
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
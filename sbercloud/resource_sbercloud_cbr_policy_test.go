@@ -0,0 +1,187 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/cbr/v3/policies"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCBRV3Policy_basic(t *testing.T) {
+	var policy policies.Policy
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRV3PolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRV3Policy_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3PolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "backup"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "time_period", "20"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.days", "MO,TU"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.execution_times.0", "06:00"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.execution_times.1", "18:00"),
+				),
+			},
+			{
+				Config: testAccCBRV3Policy_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3PolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "backup_quantity", "5"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.interval", "5"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.execution_times.0", "21:00"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCBRV3Policy_replication(t *testing.T) {
+	var policy policies.Policy
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCBRReplication(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRV3PolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRV3Policy_replication(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3PolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "replication"),
+					resource.TestCheckResourceAttr(resourceName, "destination_region", SBC_DEST_REGION),
+					resource.TestCheckResourceAttr(resourceName, "destination_project_id", SBC_DEST_PROJECT_ID),
+					resource.TestCheckResourceAttr(resourceName, "time_period", "20"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCBRV3PolicyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CbrV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cbr_policy" {
+			continue
+		}
+
+		_, err := policies.Get(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("CBR policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCBRV3PolicyExists(n string, policy *policies.Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CbrV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+		}
+
+		found, err := policies.Get(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("CBR policy not found")
+		}
+
+		*policy = *found
+
+		return nil
+	}
+}
+
+func testAccCBRV3Policy_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_policy" "test" {
+  name        = "%s"
+  type        = "backup"
+  time_period = 20
+
+  backup_cycle {
+    days            = "MO,TU"
+    execution_times = ["06:00", "18:00"]
+  }
+}
+`, rName)
+}
+
+func testAccCBRV3Policy_update(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_policy" "test" {
+  name            = "%s-update"
+  type            = "backup"
+  enabled         = false
+  backup_quantity = 5
+
+  backup_cycle {
+    interval        = 5
+    execution_times = ["21:00"]
+  }
+}
+`, rName)
+}
+
+func testAccCBRV3Policy_replication(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_policy" "test" {
+  name                   = "%s"
+  type                   = "replication"
+  destination_region     = "%s"
+  destination_project_id = "%s"
+  time_period            = 20
+
+  backup_cycle {
+    days            = "MO,TU"
+    execution_times = ["06:00"]
+  }
+}
+`, rName, SBC_DEST_REGION, SBC_DEST_PROJECT_ID)
+}
@@ -0,0 +1,326 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/cbr/v3/vaults"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCBRV3Vault_server(t *testing.T) {
+	var vault vaults.Vault
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_vault.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRV3VaultDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRV3Vault_server(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3VaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "server"),
+					resource.TestCheckResourceAttr(resourceName, "consistent_level", "crash_consistent"),
+					resource.TestCheckResourceAttr(resourceName, "protection_type", "backup"),
+					resource.TestCheckResourceAttr(resourceName, "size", "200"),
+					resource.TestCheckResourceAttr(resourceName, "resources.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "policy_id",
+						"sbercloud_cbr_policy.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccCBRV3Vault_serverUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3VaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "size", "300"),
+					resource.TestCheckResourceAttr(resourceName, "resources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "baaar"),
+				),
+			},
+			{
+				Config: testAccCBRV3Vault_serverSwap(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3VaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "resources.#", "1"),
+					testAccCheckCBRV3VaultResources(&vault, "sbercloud_compute_instance.swap"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCBRV3Vault_disk(t *testing.T) {
+	var vault vaults.Vault
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_vault.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRV3VaultDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRV3Vault_disk(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRV3VaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "disk"),
+					resource.TestCheckResourceAttr(resourceName, "size", "50"),
+					resource.TestCheckResourceAttr(resourceName, "auto_expand", "true"),
+					resource.TestCheckResourceAttr(resourceName, "resources.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestCBRV3Vault_numberInGB(t *testing.T) {
+	for megaBytes, expected := range map[float64]float64{
+		0:      0,
+		512:    0.5,
+		1000:   0.98,
+		1023:   1,
+		204800: 200,
+	} {
+		if v := getCBRNumberInGB(megaBytes); v != expected {
+			t.Fatalf("Expected %v GB for %v MB, got %v", expected, megaBytes, v)
+		}
+	}
+}
+
+func testAccCheckCBRV3VaultDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CbrV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cbr_vault" {
+			continue
+		}
+
+		_, err := vaults.Get(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("CBR vault still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCBRV3VaultExists(n string, vault *vaults.Vault) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CbrV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+		}
+
+		found, err := vaults.Get(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("CBR vault not found")
+		}
+
+		*vault = *found
+
+		return nil
+	}
+}
+
+// testAccCheckCBRV3VaultResources checks the vault holds exactly the given instances.
+func testAccCheckCBRV3VaultResources(vault *vaults.Vault, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ids := make(map[string]bool)
+		for _, n := range names {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("Not found: %s", n)
+			}
+			ids[rs.Primary.ID] = true
+		}
+
+		if len(vault.Resources) != len(ids) {
+			return fmt.Errorf("Expected %d resources in the vault, got %d", len(ids), len(vault.Resources))
+		}
+		for _, res := range vault.Resources {
+			if !ids[res.ID] {
+				return fmt.Errorf("Unexpected resource %s in the vault", res.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCBRV3Vault_serverBase(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%s"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  data_disks {
+    type = "SSD"
+    size = "10"
+  }
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+
+resource "sbercloud_cbr_policy" "test" {
+  name        = "%s"
+  type        = "backup"
+  time_period = 20
+
+  backup_cycle {
+    days            = "MO,TU"
+    execution_times = ["06:00"]
+  }
+}
+`, testAccCompute_data, rName, rName)
+}
+
+func testAccCBRV3Vault_server(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%s"
+  type             = "server"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = 200
+  policy_id        = sbercloud_cbr_policy.test.id
+
+  resources {
+    id = sbercloud_compute_instance.test.id
+  }
+
+  tags = {
+    foo = "bar"
+  }
+}
+`, testAccCBRV3Vault_serverBase(rName), rName)
+}
+
+func testAccCBRV3Vault_serverUpdate(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%s-update"
+  type             = "server"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = 300
+
+  resources {
+    id = sbercloud_compute_instance.test.id
+  }
+
+  tags = {
+    foo = "baaar"
+  }
+}
+`, testAccCBRV3Vault_serverBase(rName), rName)
+}
+
+func testAccCBRV3Vault_serverSwap(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "swap" {
+  name              = "%s-swap"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%s-update"
+  type             = "server"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = 300
+
+  resources {
+    id = sbercloud_compute_instance.swap.id
+  }
+
+  tags = {
+    foo = "baaar"
+  }
+}
+`, testAccCBRV3Vault_serverBase(rName), rName, rName)
+}
+
+func testAccCBRV3Vault_disk(rName string) string {
+	return fmt.Sprintf(`
+data "sbercloud_availability_zones" "test" {}
+
+resource "sbercloud_evs_volume" "test" {
+  name              = "%s"
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  volume_type       = "SSD"
+  size              = 50
+}
+
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%s"
+  type             = "disk"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = 50
+  auto_expand      = true
+
+  resources {
+    id = sbercloud_evs_volume.test.id
+  }
+}
+`, rName, rName)
+}
@@ -0,0 +1,352 @@
+package sbercloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/addons"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func ResourceCCEAddonV3() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCCEAddonV3Create,
+		Read:   resourceCCEAddonV3Read,
+		Update: resourceCCEAddonV3Update,
+		Delete: resourceCCEAddonV3Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceCCEAddonV3Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(3 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"template_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"values": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"basic": {
+							Type:          schema.TypeMap,
+							Optional:      true,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							ConflictsWith: []string{"values.0.basic_json"},
+						},
+						"basic_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"custom": {
+							Type:          schema.TypeMap,
+							Optional:      true,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							ConflictsWith: []string{"values.0.custom_json"},
+						},
+						"custom_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"flavor": {
+							Type:          schema.TypeMap,
+							Optional:      true,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							ConflictsWith: []string{"values.0.flavor_json"},
+						},
+						"flavor_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// cceAddonValue returns the add-on values of the given kind (basic, custom or
+// flavor), taken either from the string map or from its *_json counterpart.
+func cceAddonValue(valuesMap map[string]interface{}, kind string) (map[string]interface{}, error) {
+	if raw, ok := valuesMap[kind+"_json"].(string); ok && raw != "" {
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &result); err != nil {
+			return nil, fmt.Errorf("Error parsing %s_json: %s", kind, err)
+		}
+		return result, nil
+	}
+
+	if raw, ok := valuesMap[kind].(map[string]interface{}); ok && len(raw) > 0 {
+		return raw, nil
+	}
+	return nil, nil
+}
+
+func buildCCEAddonValues(d *schema.ResourceData) (addons.Values, error) {
+	result := addons.Values{
+		Basic: map[string]interface{}{},
+	}
+
+	values := d.Get("values").([]interface{})
+	if len(values) == 0 || values[0] == nil {
+		return result, nil
+	}
+	valuesMap := values[0].(map[string]interface{})
+
+	basic, err := cceAddonValue(valuesMap, "basic")
+	if err != nil {
+		return result, err
+	}
+	if basic != nil {
+		result.Basic = basic
+	}
+	if result.Custom, err = cceAddonValue(valuesMap, "custom"); err != nil {
+		return result, err
+	}
+	if result.Flavor, err = cceAddonValue(valuesMap, "flavor"); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func resourceCCEAddonV3Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Unable to create SberCloud CCE client : %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	values, err := buildCCEAddonValues(d)
+	if err != nil {
+		return fmt.Errorf("Error getting values for CCE addon: %s", err)
+	}
+
+	createOpts := addons.CreateOpts{
+		Kind:       "Addon",
+		ApiVersion: "v3",
+		Metadata: addons.CreateMetadata{
+			Anno: addons.Annotations{
+				AddonInstallType: "install",
+			},
+		},
+		Spec: addons.RequestSpec{
+			Version:           d.Get("version").(string),
+			ClusterID:         clusterID,
+			AddonTemplateName: d.Get("template_name").(string),
+			Values:            values,
+		},
+	}
+
+	create, err := addons.Create(cceClient, createOpts, clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE addon: %s", err)
+	}
+	d.SetId(create.Metadata.Id)
+
+	log.Printf("[DEBUG] Waiting for SberCloud CCE addon (%s) to become available", d.Id())
+	if err := waitForCCEAddonRunning(d, cceClient, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE addon: %s", err)
+	}
+
+	return resourceCCEAddonV3Read(d, meta)
+}
+
+func resourceCCEAddonV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	n, err := addons.Get(cceClient, d.Id(), d.Get("cluster_id").(string)).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud CCE addon")
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("cluster_id", n.Spec.ClusterID)
+	d.Set("version", n.Spec.Version)
+	d.Set("template_name", n.Spec.AddonTemplateName)
+	d.Set("status", n.Status.Status)
+	d.Set("description", n.Spec.Description)
+
+	return nil
+}
+
+func resourceCCEAddonV3Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	values, err := buildCCEAddonValues(d)
+	if err != nil {
+		return fmt.Errorf("Error getting values for CCE addon: %s", err)
+	}
+
+	// The SDK has no add-on update call, the upgrade request differs from the
+	// create request only in its annotation.
+	updateOpts := map[string]interface{}{
+		"kind":       "Addon",
+		"apiVersion": "v3",
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				"addon.upgrade/type": "upgrade",
+			},
+		},
+		"spec": addons.RequestSpec{
+			Version:           d.Get("version").(string),
+			ClusterID:         clusterID,
+			AddonTemplateName: d.Get("template_name").(string),
+			Values:            values,
+		},
+	}
+
+	log.Printf("[DEBUG] Updating SberCloud CCE addon %s: %#v", d.Id(), updateOpts)
+	updateURL := addons.CCEServiceURL(cceClient, clusterID, "addons", d.Id()+"?cluster_id="+clusterID)
+	_, err = cceClient.Put(updateURL, updateOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud CCE addon: %s", err)
+	}
+
+	if err := waitForCCEAddonRunning(d, cceClient, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("Error updating SberCloud CCE addon: %s", err)
+	}
+
+	return resourceCCEAddonV3Read(d, meta)
+}
+
+func resourceCCEAddonV3Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	err = addons.Delete(cceClient, d.Id(), clusterID).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud CCE addon: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Deleting", "Available", "Unavailable"},
+		Target:     []string{"Deleted"},
+		Refresh:    waitForCCEAddonDelete(cceClient, d.Id(), clusterID),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud CCE addon: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForCCEAddonRunning(d *schema.ResourceData, client *golangsdk.ServiceClient, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"installing", "upgrading", "abnormal"},
+		Target:     []string{"running"},
+		Refresh:    waitForCCEAddonActive(client, d.Id(), d.Get("cluster_id").(string)),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func waitForCCEAddonActive(cceAddonClient *golangsdk.ServiceClient, id, clusterID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := addons.Get(cceAddonClient, id, clusterID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		return n, n.Status.Status, nil
+	}
+}
+
+func waitForCCEAddonDelete(cceClient *golangsdk.ServiceClient, id, clusterID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to delete SberCloud CCE addon %s", id)
+
+		r, err := addons.Get(cceClient, id, clusterID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud CCE addon %s", id)
+				return r, "Deleted", nil
+			}
+			return nil, "", err
+		}
+		if r.Status.Status == "Deleting" {
+			return r, "Deleting", nil
+		}
+		log.Printf("[DEBUG] SberCloud CCE addon %s still available", id)
+		return r, "Available", nil
+	}
+}
+
+func resourceCCEAddonV3Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid format specified for CCE addon. Format must be <cluster id>/<addon id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("cluster_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
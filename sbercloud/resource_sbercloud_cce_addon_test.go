@@ -0,0 +1,166 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/addons"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCCEAddonV3_basic(t *testing.T) {
+	var addon addons.Addon
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_addon.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEAddonV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonV3_basic(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEAddonV3Exists(resourceName, &addon),
+					resource.TestCheckResourceAttr(resourceName, "template_name", "metrics-server"),
+					resource.TestCheckResourceAttrPair(resourceName, "version",
+						"data.sbercloud_cce_addon_template.test", "versions.1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "running"),
+				),
+			},
+			{
+				Config: testAccCCEAddonV3_basic(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEAddonV3Exists(resourceName, &addon),
+					resource.TestCheckResourceAttrPair(resourceName, "version",
+						"data.sbercloud_cce_addon_template.test", "versions.0"),
+					resource.TestCheckResourceAttr(resourceName, "status", "running"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCCEAddonV3ImportStateIdFunc(resourceName),
+				ImportStateVerifyIgnore: []string{
+					"values",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckCCEAddonV3Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	cceClient, err := config.CceAddonV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE addon client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cce_addon" {
+			continue
+		}
+
+		_, err := addons.Get(cceClient, rs.Primary.ID, rs.Primary.Attributes["cluster_id"]).Extract()
+		if err == nil {
+			return fmt.Errorf("addon still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCCEAddonV3Exists(n string, addon *addons.Addon) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		cceClient, err := config.CceAddonV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CCE addon client: %s", err)
+		}
+
+		found, err := addons.Get(cceClient, rs.Primary.ID, rs.Primary.Attributes["cluster_id"]).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Metadata.Id != rs.Primary.ID {
+			return fmt.Errorf("Addon not found")
+		}
+
+		*addon = *found
+
+		return nil
+	}
+}
+
+func testAccCCEAddonV3ImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["cluster_id"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCCEAddonV3_Base(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_node" "test" {
+  cluster_id        = sbercloud_cce_cluster.test.id
+  name              = "%s"
+  flavor_id         = "s6.large.2"
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  key_pair          = sbercloud_compute_keypair.test.name
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+}
+`, testAccCCENodePool_Base(rName), rName)
+}
+
+func testAccCCEAddonV3_basic(rName string, versionIndex int) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_cce_addon_template" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "metrics-server"
+}
+
+resource "sbercloud_cce_addon" "test" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  template_name = "metrics-server"
+  version       = data.sbercloud_cce_addon_template.test.versions[%d]
+
+  values {
+    basic_json = jsonencode(jsondecode(data.sbercloud_cce_addon_template.test.spec).basic)
+  }
+
+  depends_on = [sbercloud_cce_node.test]
+}
+`, testAccCCEAddonV3_Base(rName), versionIndex)
+}
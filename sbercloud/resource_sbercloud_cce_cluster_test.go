@@ -2,6 +2,7 @@ package sbercloud
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
@@ -102,6 +103,41 @@ func TestAccCCEClusterV3_withEpsId(t *testing.T) {
 	})
 }
 
+func TestAccCCEClusterV3_upgrade(t *testing.T) {
+	var cluster clusters.Clusters
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEClusterV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterV3_version(rName, "v1.17.9"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEClusterV3Exists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.17.9"),
+				),
+			},
+			{
+				Config: testAccCCEClusterV3_version(rName, "v1.19.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEClusterV3NotRecreated(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.19.10"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Available"),
+					resource.TestCheckResourceAttr(resourceName, "upgrade_task_id", ""),
+				),
+			},
+			{
+				Config:      testAccCCEClusterV3_version(rName, "v1.17.9"),
+				ExpectError: regexp.MustCompile("can not be downgraded"),
+			},
+		},
+	})
+}
+
 func testAccCheckCCEClusterV3Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	cceClient, err := config.CceV3Client(SBC_REGION_NAME)
@@ -155,6 +191,21 @@ func testAccCheckCCEClusterV3Exists(n string, cluster *clusters.Clusters) resour
 	}
 }
 
+func testAccCheckCCEClusterV3NotRecreated(n string, cluster *clusters.Clusters) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != cluster.Metadata.Id {
+			return fmt.Errorf("Cluster was recreated: expected %s, got %s", cluster.Metadata.Id, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCCEClusterV3_Base(rName string) string {
 	return fmt.Sprintf(`
 resource "sbercloud_vpc" "test" {
@@ -248,3 +299,43 @@ resource "sbercloud_cce_cluster" "test" {
 
 `, testAccCCEClusterV3_Base(rName), rName, SBC_ENTERPRISE_PROJECT_ID_TEST)
 }
+
+func testAccCCEClusterV3_version(rName, version string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_availability_zones" "test" {}
+
+resource "sbercloud_cce_cluster" "test" {
+  name                   = "%s"
+  flavor_id              = "cce.s1.small"
+  cluster_version        = "%s"
+  vpc_id                 = sbercloud_vpc.test.id
+  subnet_id              = sbercloud_vpc_subnet.test.id
+  container_network_type = "overlay_l2"
+
+  upgrade {
+    max_unavailable = 2
+  }
+}
+
+resource "sbercloud_cce_node_pool" "test" {
+  cluster_id         = sbercloud_cce_cluster.test.id
+  name               = "%s"
+  os                 = "EulerOS 2.5"
+  flavor_id          = "s6.large.2"
+  initial_node_count = 2
+  availability_zone  = data.sbercloud_availability_zones.test.names[0]
+  password           = "Test@123"
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+}
+`, testAccCCEClusterV3_Base(rName), rName, version, rName)
+}
@@ -0,0 +1,256 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// cceNamespace is a Kubernetes namespace served by the API server of a CCE cluster.
+type cceNamespace struct {
+	ApiVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name              string            `json:"name,omitempty"`
+		GenerateName      string            `json:"generateName,omitempty"`
+		UID               string            `json:"uid,omitempty"`
+		CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+		Labels            map[string]string `json:"labels,omitempty"`
+		Annotations       map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase,omitempty"`
+	} `json:"status"`
+}
+
+// cceNamespaceNameRegexp matches a Kubernetes DNS label.
+var cceNamespaceNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+func ResourceCCENamespaceV1() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCCENamespaceV1Create,
+		Read:   resourceCCENamespaceV1Read,
+		Delete: resourceCCENamespaceV1Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceCCENamespaceV1Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "prefix"},
+				ValidateFunc: validation.StringMatch(cceNamespaceNameRegexp,
+					"The name must consist of lower case alphanumeric characters or '-', "+
+						"and must start and end with an alphanumeric character"),
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"annotations": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"creation_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// cceKubernetesURL returns the URL of a Kubernetes API path of the cluster,
+// which CCE serves under the cluster ID subdomain of the CCE endpoint.
+func cceKubernetesURL(client *golangsdk.ServiceClient, clusterID string, parts ...string) string {
+	u, _ := url.Parse(client.Endpoint)
+	u.Host = clusterID + "." + u.Host
+	return u.String() + strings.Join(parts, "/")
+}
+
+func expandCCEStringMap(raw map[string]interface{}) map[string]string {
+	m := make(map[string]string, len(raw))
+	for key, val := range raw {
+		m[key] = val.(string)
+	}
+	return m
+}
+
+func cceNamespaceURL(client *golangsdk.ServiceClient, clusterID string, name ...string) string {
+	return cceKubernetesURL(client, clusterID, append([]string{"api", "v1", "namespaces"}, name...)...)
+}
+
+func getCCENamespace(client *golangsdk.ServiceClient, clusterID, name string) (*cceNamespace, error) {
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(cceNamespaceURL(client, clusterID, name), &rst.Body, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if rst.Err != nil {
+		return nil, rst.Err
+	}
+
+	var namespace cceNamespace
+	err := rst.ExtractInto(&namespace)
+	return &namespace, err
+}
+
+func resourceCCENamespaceV1Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	var createOpts cceNamespace
+	createOpts.ApiVersion = "v1"
+	createOpts.Kind = "Namespace"
+	createOpts.Metadata.Name = d.Get("name").(string)
+	createOpts.Metadata.GenerateName = d.Get("prefix").(string)
+	createOpts.Metadata.Labels = expandCCEStringMap(d.Get("labels").(map[string]interface{}))
+	createOpts.Metadata.Annotations = expandCCEStringMap(d.Get("annotations").(map[string]interface{}))
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	clusterID := d.Get("cluster_id").(string)
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(cceNamespaceURL(client, clusterID), createOpts, &rst.Body, &golangsdk.RequestOpts{
+		OkCodes:     []int{201},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if rst.Err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE namespace: %s", rst.Err)
+	}
+
+	var namespace cceNamespace
+	if err := rst.ExtractInto(&namespace); err != nil {
+		return fmt.Errorf("Error extracting SberCloud CCE namespace: %s", err)
+	}
+
+	d.SetId(namespace.Metadata.UID)
+	d.Set("name", namespace.Metadata.Name)
+
+	return resourceCCENamespaceV1Read(d, meta)
+}
+
+func resourceCCENamespaceV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.CceAddonV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	namespace, err := getCCENamespace(client, d.Get("cluster_id").(string), d.Get("name").(string))
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud CCE namespace")
+	}
+
+	d.SetId(namespace.Metadata.UID)
+	d.Set("region", region)
+	d.Set("name", namespace.Metadata.Name)
+	d.Set("labels", namespace.Metadata.Labels)
+	d.Set("annotations", namespace.Metadata.Annotations)
+	d.Set("creation_timestamp", namespace.Metadata.CreationTimestamp)
+	d.Set("status", namespace.Status.Phase)
+
+	return nil
+}
+
+func resourceCCENamespaceV1Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+	_, err = client.Delete(cceNamespaceURL(client, clusterID, name), &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 202},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud CCE namespace")
+	}
+
+	// The namespace is Terminating until all its resources are deleted.
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Terminating", "Active"},
+		Target:       []string{"Deleted"},
+		Refresh:      waitForCCENamespaceDelete(client, clusterID, name),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		PollInterval: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error deleting SberCloud CCE namespace: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForCCENamespaceDelete(client *golangsdk.ServiceClient, clusterID, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		namespace, err := getCCENamespace(client, clusterID, name)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud CCE namespace %s", name)
+				return namespace, "Deleted", nil
+			}
+			return nil, "", err
+		}
+		return namespace, namespace.Status.Phase, nil
+	}
+}
+
+func resourceCCENamespaceV1Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid format specified for CCE namespace. Format must be <cluster id>/<namespace name>")
+	}
+
+	d.Set("cluster_id", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
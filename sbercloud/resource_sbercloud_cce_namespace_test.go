@@ -0,0 +1,197 @@
+package sbercloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestCCENamespaceV1_stub(t *testing.T) {
+	stub := newAPIStub()
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	r := ResourceCCENamespaceV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id": "stub-cluster",
+		"name":       "test-namespace",
+		"labels": map[string]interface{}{
+			"team": "dev",
+		},
+	})
+
+	if err := resourceCCENamespaceV1Create(d, config); err != nil {
+		t.Fatalf("Error creating namespace: %s", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("No ID is set")
+	}
+	if _, ok := stub.object("/api/v1/namespaces/test-namespace"); !ok {
+		t.Fatal("The namespace was not created in the cluster")
+	}
+	for _, host := range stub.hosts {
+		if !strings.HasPrefix(host, "stub-cluster.") {
+			t.Fatalf("Request sent to %s instead of the cluster endpoint", host)
+		}
+	}
+	if v := d.Get("status").(string); v != "Active" {
+		t.Fatalf("Expected status Active, got %s", v)
+	}
+	if v := d.Get("labels.team").(string); v != "dev" {
+		t.Fatalf("Expected label team=dev, got %q", v)
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("stub-cluster/test-namespace")
+	if _, err := r.Importer.State(imported, config); err != nil {
+		t.Fatalf("Error importing namespace: %s", err)
+	}
+	if err := resourceCCENamespaceV1Read(imported, config); err != nil {
+		t.Fatalf("Error reading imported namespace: %s", err)
+	}
+	if imported.Id() != d.Id() || imported.Get("labels.team").(string) != "dev" {
+		t.Fatalf("Imported namespace %s differs from %s", imported.Id(), d.Id())
+	}
+
+	if err := resourceCCENamespaceV1Delete(d, config); err != nil {
+		t.Fatalf("Error deleting namespace: %s", err)
+	}
+	if _, ok := stub.object("/api/v1/namespaces/test-namespace"); ok {
+		t.Fatal("The namespace still exists")
+	}
+
+	if err := resourceCCENamespaceV1Read(imported, config); err != nil {
+		t.Fatalf("Error reading deleted namespace: %s", err)
+	}
+	if imported.Id() != "" {
+		t.Fatal("The ID of a deleted namespace is not cleared")
+	}
+}
+
+func TestCCENamespaceV1_prefix(t *testing.T) {
+	stub := newAPIStub()
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, ResourceCCENamespaceV1().Schema, map[string]interface{}{
+		"cluster_id": "stub-cluster",
+		"prefix":     "test-",
+	})
+
+	if err := resourceCCENamespaceV1Create(d, config); err != nil {
+		t.Fatalf("Error creating namespace: %s", err)
+	}
+	name := d.Get("name").(string)
+	if !strings.HasPrefix(name, "test-") || name == "test-" {
+		t.Fatalf("Expected a name generated from the prefix, got %q", name)
+	}
+	if _, ok := stub.object("/api/v1/namespaces/" + name); !ok {
+		t.Fatalf("The namespace %s was not created in the cluster", name)
+	}
+}
+
+func TestAccCCENamespaceV1_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_namespace.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCENamespaceV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENamespaceV1_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENamespaceV1Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "labels.team", "dev"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Active"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCCENamespaceV1ImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccCheckCCENamespaceV1Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CceAddonV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cce_namespace" {
+			continue
+		}
+
+		_, err := getCCENamespace(client, rs.Primary.Attributes["cluster_id"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("CCE namespace %s still exists", rs.Primary.Attributes["name"])
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCCENamespaceV1Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CceAddonV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+		}
+
+		_, err = getCCENamespace(client, rs.Primary.Attributes["cluster_id"], rs.Primary.Attributes["name"])
+		return err
+	}
+}
+
+func testAccCCENamespaceV1ImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["cluster_id"], rs.Primary.Attributes["name"]), nil
+	}
+}
+
+func testAccCCENamespaceV1_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_namespace" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "%s"
+
+  labels = {
+    team = "dev"
+  }
+}
+`, testAccCCEClusterV3_basic(rName), rName)
+}
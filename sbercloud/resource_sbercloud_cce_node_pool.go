@@ -0,0 +1,1254 @@
+package sbercloud
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/addons"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/nodepools"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/nodes"
+	"github.com/huaweicloud/golangsdk/openstack/common/tags"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// cceNodePoolTemplateKeys are the node template arguments which only apply to
+// newly created nodes. Changing them replaces the node pool, unless a
+// rolling_update strategy is configured.
+var cceNodePoolTemplateKeys = []string{
+	"flavor_id", "os", "root_volume", "data_volumes", "preinstall", "postinstall", "runtime",
+}
+
+// cceNodePoolIDAnnotation is the node annotation which holds the ID of the node
+// pool a node belongs to, either as "<pool_id>" or as "<az>#<pool_id>".
+const cceNodePoolIDAnnotation = "kubernetes.io/node-pool.id"
+
+// The delays before the node pool and its nodes are polled after a change.
+var (
+	cceNodePoolCreateDelay = 120 * time.Second
+	cceNodePoolUpdateDelay = 15 * time.Second
+	cceNodeChangeDelay     = 30 * time.Second
+)
+
+func ResourceCCENodePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCCENodePoolCreate,
+		Read:   resourceCCENodePoolRead,
+		Update: resourceCCENodePoolUpdate,
+		Delete: resourceCCENodePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCCENodePoolV3Import,
+		},
+
+		CustomizeDiff: resourceCCENodePoolCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"initial_node_count": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The node template arguments are ForceNew unless rolling_update is set,
+			// see resourceCCENodePoolCustomizeDiff.
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"labels": { //(k8s_tags)
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"root_volume": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"size": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"volumetype": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"hw_passthrough": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"extend_param": {
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "use extend_params instead",
+						},
+						"extend_params": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					}},
+			},
+			"data_volumes": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"size": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"volumetype": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"hw_passthrough": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"extend_param": {
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "use extend_params instead",
+						},
+						"extend_params": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					}},
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "random",
+			},
+			"os": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"key_pair": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"password", "key_pair"},
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "key_pair"},
+			},
+			"taints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"effect": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"NoSchedule", "PreferNoSchedule", "NoExecute",
+							}, false),
+						},
+					}},
+			},
+			"tags": tagsSchema(),
+			"billing_mode": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_pods": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"preinstall": {
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(v interface{}) string {
+					switch v.(type) {
+					case string:
+						return cceInstallScriptHashSum(v.(string))
+					default:
+						return ""
+					}
+				},
+			},
+			"postinstall": {
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(v interface{}) string {
+					switch v.(type) {
+					case string:
+						return cceInstallScriptHashSum(v.(string))
+					default:
+						return ""
+					}
+				},
+			},
+			"runtime": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"docker", "containerd",
+				}, false),
+			},
+			"extend_param": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"scall_enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"min_node_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_node_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"scale_down_cooldown_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"current_node_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rolling_update": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_surge": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"outdated_nodes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// rolling_update_pending is only planned to change to resume an
+			// interrupted rolling update, outdated_nodes stays known in the plan.
+			"rolling_update_pending": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCCENodePoolTags(d *schema.ResourceData) []tags.ResourceTag {
+	tagRaw := d.Get("tags").(map[string]interface{})
+	return utils.ExpandResourceTags(tagRaw)
+}
+
+func resourceCCENodePoolK8sTags(d *schema.ResourceData) map[string]string {
+	m := make(map[string]string)
+	for key, val := range d.Get("labels").(map[string]interface{}) {
+		m[key] = val.(string)
+	}
+	return m
+}
+
+func resourceCCENodePoolRootVolume(d *schema.ResourceData) nodes.VolumeSpec {
+	var root nodes.VolumeSpec
+	volumeRaw := d.Get("root_volume").([]interface{})
+	if len(volumeRaw) == 1 {
+		rawMap := volumeRaw[0].(map[string]interface{})
+		root.Size = rawMap["size"].(int)
+		root.VolumeType = rawMap["volumetype"].(string)
+		root.HwPassthrough = rawMap["hw_passthrough"].(bool)
+		root.ExtendParam = rawMap["extend_params"].(map[string]interface{})
+	}
+	return root
+}
+
+func resourceCCENodePoolDataVolume(d *schema.ResourceData) []nodes.VolumeSpec {
+	volumeRaw := d.Get("data_volumes").([]interface{})
+	volumes := make([]nodes.VolumeSpec, len(volumeRaw))
+	for i, raw := range volumeRaw {
+		rawMap := raw.(map[string]interface{})
+		volumes[i] = nodes.VolumeSpec{
+			Size:          rawMap["size"].(int),
+			VolumeType:    rawMap["volumetype"].(string),
+			HwPassthrough: rawMap["hw_passthrough"].(bool),
+			ExtendParam:   rawMap["extend_params"].(map[string]interface{}),
+		}
+	}
+	return volumes
+}
+
+func resourceCCENodePoolTaint(d *schema.ResourceData) []nodes.TaintSpec {
+	return expandCCENodePoolTaints(d.Get("taints").([]interface{}))
+}
+
+func expandCCENodePoolTaints(taintRaw []interface{}) []nodes.TaintSpec {
+	taints := make([]nodes.TaintSpec, len(taintRaw))
+	for i, raw := range taintRaw {
+		rawMap := raw.(map[string]interface{})
+		taints[i] = nodes.TaintSpec{
+			Key:    rawMap["key"].(string),
+			Value:  rawMap["value"].(string),
+			Effect: rawMap["effect"].(string),
+		}
+	}
+	return taints
+}
+
+func resourceCCENodePoolExtendParam(d *schema.ResourceData) map[string]interface{} {
+	extendParam := make(map[string]interface{})
+	if v, ok := d.GetOk("extend_param"); ok {
+		for key, val := range v.(map[string]interface{}) {
+			extendParam[key] = val.(string)
+		}
+		if v, ok := extendParam["periodNum"]; ok {
+			periodNum, err := strconv.Atoi(v.(string))
+			if err != nil {
+				log.Printf("[WARNING] PeriodNum %s invalid, Type conversion error: %s", v.(string), err)
+			}
+			extendParam["periodNum"] = periodNum
+		}
+	}
+
+	if v, ok := d.GetOk("max_pods"); ok {
+		extendParam["maxPods"] = v.(int)
+	}
+	if v, ok := d.GetOk("preinstall"); ok {
+		extendParam["alpha.cce/preInstall"] = cceInstallScriptEncode(v.(string))
+	}
+	if v, ok := d.GetOk("postinstall"); ok {
+		extendParam["alpha.cce/postInstall"] = cceInstallScriptEncode(v.(string))
+	}
+
+	return extendParam
+}
+
+func resourceCCENodePoolLogin(d *schema.ResourceData) nodes.LoginSpec {
+	var loginSpec nodes.LoginSpec
+	if v, ok := d.GetOk("key_pair"); ok {
+		loginSpec = nodes.LoginSpec{
+			SshKey: v.(string),
+		}
+	} else if v, ok := d.GetOk("password"); ok {
+		loginSpec = nodes.LoginSpec{
+			UserPassword: nodes.UserPassword{
+				Username: "root",
+				Password: v.(string),
+			},
+		}
+	}
+	return loginSpec
+}
+
+// resourceCCENodePoolTemplate returns the node template of the node pool,
+// without the login parameters.
+func resourceCCENodePoolTemplate(d *schema.ResourceData) nodes.Spec {
+	template := nodes.Spec{
+		Flavor:      d.Get("flavor_id").(string),
+		Az:          d.Get("availability_zone").(string),
+		Os:          d.Get("os").(string),
+		RootVolume:  resourceCCENodePoolRootVolume(d),
+		DataVolumes: resourceCCENodePoolDataVolume(d),
+		K8sTags:     resourceCCENodePoolK8sTags(d),
+		BillingMode: 0,
+		Count:       1,
+		NodeNicSpec: nodes.NodeNicSpec{
+			PrimaryNic: nodes.PrimaryNic{
+				SubnetId: d.Get("subnet_id").(string),
+			},
+		},
+		ExtendParam: resourceCCENodePoolExtendParam(d),
+		Taints:      resourceCCENodePoolTaint(d),
+		UserTags:    resourceCCENodePoolTags(d),
+	}
+
+	if v, ok := d.GetOk("runtime"); ok {
+		template.RunTime = &nodes.RunTimeSpec{
+			Name: v.(string),
+		}
+	}
+	return template
+}
+
+func resourceCCENodePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE Node Pool client: %s", err)
+	}
+
+	// wait for the cce cluster to become available
+	clusterid := d.Get("cluster_id").(string)
+	if d.Get("scall_enable").(bool) {
+		if err := checkCCEAutoscalerAddon(d, config, clusterid); err != nil {
+			return err
+		}
+	}
+
+	stateCluster := &resource.StateChangeConf{
+		Target:     []string{"Available"},
+		Refresh:    waitForCCEClusterActive(nodePoolClient, clusterid),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err = stateCluster.WaitForState()
+
+	initialNodeCount := d.Get("initial_node_count").(int)
+	createOpts := nodepools.CreateOpts{
+		Kind:       "NodePool",
+		ApiVersion: "v3",
+		Metadata: nodepools.CreateMetaData{
+			Name: d.Get("name").(string),
+		},
+		Spec: nodepools.CreateSpec{
+			Type:         d.Get("type").(string),
+			NodeTemplate: resourceCCENodePoolTemplate(d),
+			Autoscaling: nodepools.AutoscalingSpec{
+				Enable:                d.Get("scall_enable").(bool),
+				MinNodeCount:          d.Get("min_node_count").(int),
+				MaxNodeCount:          d.Get("max_node_count").(int),
+				ScaleDownCooldownTime: d.Get("scale_down_cooldown_time").(int),
+				Priority:              d.Get("priority").(int),
+			},
+			InitialNodeCount: &initialNodeCount,
+		},
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	// Add loginSpec here so it wouldn't go in the above log entry
+	createOpts.Spec.NodeTemplate.Login = resourceCCENodePoolLogin(d)
+
+	s, err := nodepools.Create(nodePoolClient, clusterid, createOpts).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault403); ok {
+			retryNode, err := recursiveNodePoolCreate(nodePoolClient, createOpts, clusterid, 403)
+			if err == "fail" {
+				return fmt.Errorf("Error creating SberCloud Node Pool")
+			}
+			s = retryNode
+		} else {
+			return fmt.Errorf("Error creating SberCloud Node Pool: %s", err)
+		}
+	}
+
+	if len(s.Metadata.Id) == 0 {
+		return fmt.Errorf("Error fetching CreateNodePool id")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Synchronizing"},
+		Target:       []string{""},
+		Refresh:      waitForCceNodePoolActive(nodePoolClient, clusterid, s.Metadata.Id),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        cceNodePoolCreateDelay,
+		PollInterval: 20 * time.Second,
+	}
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE Node Pool: %s", err)
+	}
+
+	log.Printf("[DEBUG] Create node pool: %v", s)
+
+	d.SetId(s.Metadata.Id)
+	return resourceCCENodePoolRead(d, meta)
+}
+
+func resourceCCENodePoolRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE Node Pool client: %s", err)
+	}
+	clusterid := d.Get("cluster_id").(string)
+	s, err := nodepools.Get(nodePoolClient, clusterid, d.Id()).Extract()
+
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Node Pool")
+	}
+
+	d.Set("name", s.Metadata.Name)
+	d.Set("flavor_id", s.Spec.NodeTemplate.Flavor)
+	d.Set("availability_zone", s.Spec.NodeTemplate.Az)
+	d.Set("os", s.Spec.NodeTemplate.Os)
+	d.Set("billing_mode", s.Spec.NodeTemplate.BillingMode)
+	d.Set("key_pair", s.Spec.NodeTemplate.Login.SshKey)
+	// The autoscaler resizes the node pool, so the configured node count is kept
+	// and the actual one is reported as current_node_count.
+	if !s.Spec.Autoscaling.Enable || d.Get("initial_node_count").(int) == 0 {
+		d.Set("initial_node_count", s.Spec.InitialNodeCount)
+	}
+	d.Set("current_node_count", s.Status.CurrentNode)
+	d.Set("scall_enable", s.Spec.Autoscaling.Enable)
+	d.Set("min_node_count", s.Spec.Autoscaling.MinNodeCount)
+	d.Set("max_node_count", s.Spec.Autoscaling.MaxNodeCount)
+	d.Set("scale_down_cooldown_time", s.Spec.Autoscaling.ScaleDownCooldownTime)
+	d.Set("priority", s.Spec.Autoscaling.Priority)
+	d.Set("type", s.Spec.Type)
+
+	// set extend_param
+	var extend_param = s.Spec.NodeTemplate.ExtendParam
+	if maxPods, ok := extend_param["maxPods"].(float64); ok {
+		d.Set("max_pods", maxPods)
+	}
+	delete(extend_param, "maxPods")
+	delete(extend_param, "alpha.cce/preInstall")
+	delete(extend_param, "alpha.cce/postInstall")
+	d.Set("extend_param", extend_param)
+
+	if s.Spec.NodeTemplate.RunTime != nil {
+		d.Set("runtime", s.Spec.NodeTemplate.RunTime.Name)
+	}
+
+	labels := map[string]string{}
+	for key, val := range s.Spec.NodeTemplate.K8sTags {
+		if strings.Contains(key, "cce.cloud.com") {
+			continue
+		}
+		labels[key] = val
+	}
+	d.Set("labels", labels)
+
+	taints := make([]map[string]interface{}, len(s.Spec.NodeTemplate.Taints))
+	for i, taint := range s.Spec.NodeTemplate.Taints {
+		taints[i] = map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": taint.Effect,
+		}
+	}
+	if err := d.Set("taints", taints); err != nil {
+		return fmt.Errorf("Error saving taints to state for SberCloud Node Pool (%s): %s", d.Id(), err)
+	}
+
+	var volumes []map[string]interface{}
+	for _, pairObject := range s.Spec.NodeTemplate.DataVolumes {
+		volume := make(map[string]interface{})
+		volume["size"] = pairObject.Size
+		volume["volumetype"] = pairObject.VolumeType
+		volume["hw_passthrough"] = pairObject.HwPassthrough
+		volume["extend_params"] = pairObject.ExtendParam
+		volume["extend_param"] = ""
+		volumes = append(volumes, volume)
+	}
+	if err := d.Set("data_volumes", volumes); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving dataVolumes to state for SberCloud Node Pool (%s): %s", d.Id(), err)
+	}
+
+	rootVolume := []map[string]interface{}{
+		{
+			"size":           s.Spec.NodeTemplate.RootVolume.Size,
+			"volumetype":     s.Spec.NodeTemplate.RootVolume.VolumeType,
+			"hw_passthrough": s.Spec.NodeTemplate.RootVolume.HwPassthrough,
+			"extend_params":  s.Spec.NodeTemplate.RootVolume.ExtendParam,
+			"extend_param":   "",
+		},
+	}
+	if err := d.Set("root_volume", rootVolume); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving root Volume to state for SberCloud Node Pool (%s): %s", d.Id(), err)
+	}
+
+	tagmap := utils.TagsToMap(s.Spec.NodeTemplate.UserTags)
+	// ignore "CCE-Dynamic-Provisioning-Node"
+	delete(tagmap, "CCE-Dynamic-Provisioning-Node")
+	if err := d.Set("tags", tagmap); err != nil {
+		return fmt.Errorf("Error saving tags to state for CCE Node Pool(%s): %s", d.Id(), err)
+	}
+
+	d.Set("status", s.Status.Phase)
+	d.Set("rolling_update_pending", len(d.Get("outdated_nodes").([]interface{})) > 0)
+
+	return nil
+}
+
+func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	d.Partial(true)
+	d.SetPartial("outdated_nodes")
+
+	// Record the nodes created from the previous node template before the
+	// template is changed, they are replaced by the rolling update below.
+	if d.HasChanges(cceNodePoolTemplateKeys...) {
+		poolNodes, err := listCCENodePoolNodes(nodePoolClient, d.Get("cluster_id").(string), d.Id())
+		if err != nil {
+			return fmt.Errorf("Error listing nodes of SberCloud CCE Node Pool: %s", err)
+		}
+
+		o, _ := d.GetChange("outdated_nodes")
+		outdated := o.([]interface{})
+		recorded := make(map[string]bool, len(outdated))
+		for _, id := range outdated {
+			recorded[id.(string)] = true
+		}
+		for id := range poolNodes {
+			if !recorded[id] {
+				outdated = append(outdated, id)
+			}
+		}
+		d.Set("outdated_nodes", outdated)
+	}
+
+	clusterid := d.Get("cluster_id").(string)
+	if d.Get("scall_enable").(bool) && d.HasChanges("scall_enable", "min_node_count", "max_node_count") {
+		if err := checkCCEAutoscalerAddon(d, config, clusterid); err != nil {
+			return err
+		}
+	}
+
+	// The existing nodes are synced before the node template is updated, so a
+	// failed sync is retried by the next apply.
+	if d.HasChanges("labels", "taints") {
+		if err := syncCCENodePoolNodes(d, nodePoolClient); err != nil {
+			return fmt.Errorf("Error syncing labels and taints to the nodes of SberCloud CCE Node Pool: %s", err)
+		}
+	}
+
+	if err := updateCCENodePool(d, nodePoolClient, cceNodePoolNodeCount(d)); err != nil {
+		return err
+	}
+	// The node pool holds the new node template now. If the rolling update
+	// fails, the next apply must not see a template change again, otherwise
+	// the nodes just built from the new template are recorded as outdated.
+	for _, key := range cceNodePoolTemplateKeys {
+		d.SetPartial(key)
+	}
+
+	if len(d.Get("outdated_nodes").([]interface{})) > 0 {
+		if err := rollCCENodePool(d, nodePoolClient); err != nil {
+			return fmt.Errorf("Error in the rolling update of SberCloud CCE Node Pool, "+
+				"apply again to resume the rolling update: %s", err)
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceCCENodePoolRead(d, meta)
+}
+
+// updateCCENodePool updates the node pool with the given node count and waits
+// for the node pool to be synchronized.
+func updateCCENodePool(d *schema.ResourceData, client *golangsdk.ServiceClient, nodeCount int) error {
+	template := resourceCCENodePoolTemplate(d)
+	template.Login = resourceCCENodePoolLogin(d)
+
+	updateOpts := nodepools.UpdateOpts{
+		Kind:       "NodePool",
+		ApiVersion: "v3",
+		Metadata: nodepools.UpdateMetaData{
+			Name: d.Get("name").(string),
+		},
+		Spec: nodepools.UpdateSpec{
+			InitialNodeCount: &nodeCount,
+			Autoscaling: nodepools.AutoscalingSpec{
+				Enable:                d.Get("scall_enable").(bool),
+				MinNodeCount:          d.Get("min_node_count").(int),
+				MaxNodeCount:          d.Get("max_node_count").(int),
+				ScaleDownCooldownTime: d.Get("scale_down_cooldown_time").(int),
+				Priority:              d.Get("priority").(int),
+			},
+			NodeTemplate: template,
+			Type:         d.Get("type").(string),
+		},
+	}
+
+	clusterid := d.Get("cluster_id").(string)
+	_, err := nodepools.Update(client, clusterid, d.Id(), updateOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud Node Node Pool: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Synchronizing"},
+		Target:     []string{""},
+		Refresh:    waitForCceNodePoolActive(client, clusterid, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      cceNodePoolUpdateDelay,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud CCE Node Pool: %s", err)
+	}
+	return nil
+}
+
+// cceNodePoolNodeCount returns the node count to request when updating the
+// node pool. When the autoscaler manages the pool, its current size is kept
+// unless initial_node_count was changed.
+func cceNodePoolNodeCount(d *schema.ResourceData) int {
+	if d.Get("scall_enable").(bool) && !d.HasChange("initial_node_count") {
+		if current := d.Get("current_node_count").(int); current > 0 {
+			return current
+		}
+	}
+	return d.Get("initial_node_count").(int)
+}
+
+// checkCCEAutoscalerAddon checks that the autoscaler add-on is running in the
+// cluster, without it the autoscaling policy of the node pool has no effect.
+func checkCCEAutoscalerAddon(d *schema.ResourceData, config *config.Config, clusterID string) error {
+	addonClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE addon client: %s", err)
+	}
+
+	autoscalers, err := addons.List(addonClient, clusterID, addons.ListOpts{
+		AddonTemplateName: "autoscaler",
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving the autoscaler add-on of CCE cluster %s: %s", clusterID, err)
+	}
+	if len(autoscalers) == 0 {
+		return fmt.Errorf("scall_enable requires the autoscaler add-on, install it in CCE cluster %s "+
+			"with a sbercloud_cce_addon resource first", clusterID)
+	}
+	if status := autoscalers[0].Status.Status; status != "running" {
+		return fmt.Errorf("The autoscaler add-on of CCE cluster %s is %s, it must be running to enable autoscaling",
+			clusterID, status)
+	}
+	return nil
+}
+
+// syncCCENodePoolNodes applies the changes of labels and taints to the existing
+// nodes of the node pool. Labels and taints which were not set through the
+// node pool are kept.
+func syncCCENodePoolNodes(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	clusterID := d.Get("cluster_id").(string)
+	oldLabels, newLabels := d.GetChange("labels")
+	oldTaints, newTaints := d.GetChange("taints")
+	removedTaints := expandCCENodePoolTaints(oldTaints.([]interface{}))
+	addedTaints := expandCCENodePoolTaints(newTaints.([]interface{}))
+
+	poolNodes, err := listCCENodePoolNodes(client, clusterID, d.Id())
+	if err != nil {
+		return err
+	}
+
+	for id, node := range poolNodes {
+		if node.Status.Phase == "Deleting" {
+			continue
+		}
+
+		k8sTags := make(map[string]string)
+		for key, val := range node.Spec.K8sTags {
+			k8sTags[key] = val
+		}
+		for key := range oldLabels.(map[string]interface{}) {
+			delete(k8sTags, key)
+		}
+		for key, val := range newLabels.(map[string]interface{}) {
+			k8sTags[key] = val.(string)
+		}
+
+		taints := make([]nodes.TaintSpec, 0, len(node.Spec.Taints)+len(addedTaints))
+		for _, taint := range node.Spec.Taints {
+			if !cceTaintsContain(removedTaints, taint) && !cceTaintsContain(addedTaints, taint) {
+				taints = append(taints, taint)
+			}
+		}
+		taints = append(taints, addedTaints...)
+
+		labelsSynced := len(k8sTags) == len(node.Spec.K8sTags) && (len(k8sTags) == 0 || reflect.DeepEqual(k8sTags, node.Spec.K8sTags))
+		taintsSynced := len(taints) == len(node.Spec.Taints) && (len(taints) == 0 || reflect.DeepEqual(taints, node.Spec.Taints))
+		if labelsSynced && taintsSynced {
+			continue
+		}
+
+		// The node update request replaces the Kubernetes labels and taints of
+		// the node.
+		updateOpts := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": node.Metadata.Name,
+			},
+			"spec": map[string]interface{}{
+				"k8sTags": k8sTags,
+				"taints":  taints,
+			},
+		}
+		log.Printf("[DEBUG] Updating labels and taints of CCE node %s: %#v", id, updateOpts)
+		_, err := client.Put(client.ServiceURL("clusters", clusterID, "nodes", id), updateOpts, nil,
+			&golangsdk.RequestOpts{
+				OkCodes:     []int{200},
+				MoreHeaders: nodes.RequestOpts.MoreHeaders,
+			})
+		if err != nil {
+			return fmt.Errorf("Error updating node %s: %s", id, err)
+		}
+	}
+	return nil
+}
+
+// cceTaintsContain reports whether taints has a taint with the same key and
+// effect as taint.
+func cceTaintsContain(taints []nodes.TaintSpec, taint nodes.TaintSpec) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
+
+// rollCCENodePool replaces the outdated nodes of the node pool in batches. Each
+// batch scales the pool out by up to max_surge nodes built from the new node
+// template, drains and deletes up to max_surge + max_unavailable outdated
+// nodes and scales the pool back to initial_node_count. outdated_nodes is
+// updated after every batch, so an interrupted rolling update is resumed by
+// the next apply.
+func rollCCENodePool(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	clusterID := d.Get("cluster_id").(string)
+	nodeCount := cceNodePoolNodeCount(d)
+	maxSurge, maxUnavailable := 1, 0
+	if _, ok := d.GetOk("rolling_update"); ok {
+		maxSurge = d.Get("rolling_update.0.max_surge").(int)
+		maxUnavailable = d.Get("rolling_update.0.max_unavailable").(int)
+	}
+
+	for {
+		poolNodes, err := listCCENodePoolNodes(client, clusterID, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error listing nodes: %s", err)
+		}
+
+		// Nodes which were deleted in the meantime no longer need replacing.
+		outdated := make([]string, 0)
+		for _, id := range d.Get("outdated_nodes").([]interface{}) {
+			if _, ok := poolNodes[id.(string)]; ok {
+				outdated = append(outdated, id.(string))
+			}
+		}
+		d.Set("outdated_nodes", outdated)
+		if len(outdated) == 0 {
+			return nil
+		}
+
+		batch := outdated
+		if len(batch) > maxSurge+maxUnavailable {
+			batch = batch[:maxSurge+maxUnavailable]
+		}
+		surge := maxSurge
+		if surge > len(batch) {
+			surge = len(batch)
+		}
+		log.Printf("[DEBUG] Replacing nodes %v of CCE node pool %s, %d outdated nodes left", batch, d.Id(), len(outdated))
+
+		if surge > 0 {
+			if err := scaleCCENodePool(d, client, nodeCount+surge); err != nil {
+				return err
+			}
+		}
+
+		// Deleting a node of a node pool also decreases the node count of the pool.
+		for _, nodeID := range batch {
+			if err := drainCCENode(d, client, clusterID, nodeID); err != nil {
+				return fmt.Errorf("Error draining node %s: %s", nodeID, err)
+			}
+			if err := deleteCCENode(d, client, clusterID, nodeID); err != nil {
+				return fmt.Errorf("Error deleting node %s: %s", nodeID, err)
+			}
+		}
+		d.Set("outdated_nodes", outdated[len(batch):])
+
+		if err := scaleCCENodePool(d, client, nodeCount); err != nil {
+			return err
+		}
+	}
+}
+
+// scaleCCENodePool sets the node count of the node pool and waits for all its
+// nodes to become active.
+func scaleCCENodePool(d *schema.ResourceData, client *golangsdk.ServiceClient, nodeCount int) error {
+	log.Printf("[DEBUG] Scaling CCE node pool %s to %d nodes", d.Id(), nodeCount)
+	if err := updateCCENodePool(d, client, nodeCount); err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Pending"},
+		Target:       []string{"Active"},
+		Refresh:      waitForCCENodePoolNodesActive(client, d.Get("cluster_id").(string), d.Id(), nodeCount),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        cceNodeChangeDelay,
+		PollInterval: 20 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for the nodes of the node pool to become active: %s", err)
+	}
+	return nil
+}
+
+// cceNodeInPool reports whether the node belongs to the given node pool.
+func cceNodeInPool(node *nodes.Nodes, poolID string) bool {
+	v := node.Metadata.Annotations[cceNodePoolIDAnnotation]
+	return v == poolID || strings.HasSuffix(v, "#"+poolID)
+}
+
+// listCCENodePoolNodes returns the nodes of the node pool by node ID.
+func listCCENodePoolNodes(client *golangsdk.ServiceClient, clusterID, poolID string) (map[string]nodes.Nodes, error) {
+	allNodes, err := nodes.List(client, clusterID, nodes.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]nodes.Nodes)
+	for i := range allNodes {
+		if cceNodeInPool(&allNodes[i], poolID) {
+			result[allNodes[i].Metadata.Id] = allNodes[i]
+		}
+	}
+	return result, nil
+}
+
+func waitForCCENodePoolNodesActive(client *golangsdk.ServiceClient, clusterID, poolID string, nodeCount int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		poolNodes, err := listCCENodePoolNodes(client, clusterID, poolID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		active := 0
+		for id, node := range poolNodes {
+			switch node.Status.Phase {
+			case "Active":
+				active++
+			case "Error":
+				return poolNodes, node.Status.Phase, fmt.Errorf("node %s is in Error state: %s", id, node.Status.Message)
+			}
+		}
+
+		log.Printf("[DEBUG] %d of %d nodes of CCE node pool %s are active", active, nodeCount, poolID)
+		if active < nodeCount {
+			return poolNodes, "Pending", nil
+		}
+		return poolNodes, "Active", nil
+	}
+}
+
+// drainCCENode cordons the node and evicts its pods through a CCE drain job.
+func drainCCENode(d *schema.ResourceData, client *golangsdk.ServiceClient, clusterID, nodeID string) error {
+	drainOpts := map[string]interface{}{
+		"apiVersion": "v3",
+		"kind":       "DrainNodesTask",
+		"spec": map[string]interface{}{
+			"ignoreDaemonSet":    true,
+			"deleteLocalData":    true,
+			"gracePeriodSeconds": -1,
+		},
+	}
+
+	log.Printf("[DEBUG] Draining CCE node %s", nodeID)
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(client.ServiceURL("clusters", clusterID, "nodes", nodeID, "drain"), drainOpts, &rst.Body,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200, 201},
+			MoreHeaders: nodes.RequestOpts.MoreHeaders,
+		})
+	if rst.Err != nil {
+		return rst.Err
+	}
+
+	var job struct {
+		Status struct {
+			JobID string `json:"jobID"`
+		} `json:"status"`
+	}
+	if err := rst.ExtractInto(&job); err != nil {
+		return err
+	}
+	if job.Status.JobID == "" {
+		return nil
+	}
+
+	stateJob := &resource.StateChangeConf{
+		Pending:      []string{"Initializing", "Running"},
+		Target:       []string{"Success"},
+		Refresh:      waitForCCEJobStatus(client, job.Status.JobID),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	_, err := stateJob.WaitForState()
+	return err
+}
+
+func deleteCCENode(d *schema.ResourceData, client *golangsdk.ServiceClient, clusterID, nodeID string) error {
+	log.Printf("[DEBUG] Deleting CCE node %s", nodeID)
+	if err := nodes.Delete(client, clusterID, nodeID).ExtractErr(); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Deleting"},
+		Target:       []string{"Deleted"},
+		Refresh:      waitForCCENodeDelete(client, clusterID, nodeID),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        cceNodeChangeDelay,
+		PollInterval: 20 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func waitForCCENodeDelete(client *golangsdk.ServiceClient, clusterID, nodeID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := nodes.Get(client, clusterID, nodeID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return n, "Deleted", nil
+			}
+			return nil, "", err
+		}
+		return n, "Deleting", nil
+	}
+}
+
+func resourceCCENodePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+	clusterid := d.Get("cluster_id").(string)
+	err = nodepools.Delete(nodePoolClient, clusterid, d.Id()).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud CCE Node Pool: %s", err)
+	}
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Deleting"},
+		Target:       []string{"Deleted"},
+		Refresh:      waitForCceNodePoolDelete(nodePoolClient, clusterid, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        60 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud CCE Node Pool: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceCCENodePoolCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	_, rollingUpdate := d.GetOk("rolling_update")
+	if rollingUpdate && d.Get("rolling_update.0.max_surge").(int)+d.Get("rolling_update.0.max_unavailable").(int) < 1 {
+		return fmt.Errorf("one of rolling_update.0.max_surge and rolling_update.0.max_unavailable must be greater than 0")
+	}
+	if d.Get("scall_enable").(bool) {
+		minCount, maxCount := d.Get("min_node_count").(int), d.Get("max_node_count").(int)
+		if maxCount < 1 || minCount > maxCount {
+			return fmt.Errorf("max_node_count must be greater than 0 and not less than min_node_count " +
+				"when scall_enable is true")
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	if !rollingUpdate {
+		for _, key := range cceNodePoolTemplateKeys {
+			if d.HasChange(key) {
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// A rolling update which was interrupted is resumed by the next apply.
+	if len(d.Get("outdated_nodes").([]interface{})) > 0 {
+		return d.SetNewComputed("rolling_update_pending")
+	}
+	return nil
+}
+
+func waitForCceNodePoolActive(cceClient *golangsdk.ServiceClient, clusterId, nodePoolId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := nodepools.Get(cceClient, clusterId, nodePoolId).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return n, n.Status.Phase, nil
+	}
+}
+
+func waitForCceNodePoolDelete(cceClient *golangsdk.ServiceClient, clusterId, nodePoolId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to delete SberCloud CCE Node Pool %s.\n", nodePoolId)
+
+		r, err := nodepools.Get(cceClient, clusterId, nodePoolId).Extract()
+
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud CCE Node Pool %s", nodePoolId)
+				return r, "Deleted", nil
+			}
+			return r, "Deleting", err
+		}
+
+		log.Printf("[DEBUG] SberCloud CCE Node Pool %s still available.\n", nodePoolId)
+		return r, r.Status.Phase, nil
+	}
+}
+
+func recursiveNodePoolCreate(cceClient *golangsdk.ServiceClient, opts nodepools.CreateOptsBuilder, ClusterID string, errCode int) (*nodepools.NodePool, string) {
+	if errCode == 403 {
+		stateCluster := &resource.StateChangeConf{
+			Target:     []string{"Available"},
+			Refresh:    waitForCCEClusterActive(cceClient, ClusterID),
+			Timeout:    15 * time.Minute,
+			Delay:      15 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		_, stateErr := stateCluster.WaitForState()
+		if stateErr != nil {
+			log.Printf("[INFO] Cluster Unavailable %s.\n", stateErr)
+		}
+		s, err := nodepools.Create(cceClient, ClusterID, opts).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault403); ok {
+				return recursiveNodePoolCreate(cceClient, opts, ClusterID, 403)
+			} else {
+				return s, "fail"
+			}
+		} else {
+			return s, "success"
+		}
+	}
+	return nil, "fail"
+}
+
+func resourceCCENodePoolV3Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		err := fmt.Errorf("Invalid format specified for CCE Node Pool. Format must be <cluster id>/<node pool id>")
+		return nil, err
+	}
+
+	clusterID := parts[0]
+	nodePoolID := parts[1]
+
+	d.SetId(nodePoolID)
+	d.Set("cluster_id", clusterID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func cceInstallScriptHashSum(script string) string {
+	// Check whether the preinstall/postinstall is not Base64 encoded.
+	// Always calculate hash of base64 decoded value since we
+	// check against double-encoding when setting it
+	v, base64DecodeError := base64.StdEncoding.DecodeString(script)
+	if base64DecodeError != nil {
+		v = []byte(script)
+	}
+
+	hash := sha1.Sum(v)
+	return hex.EncodeToString(hash[:])
+}
+
+func cceInstallScriptEncode(script string) string {
+	if _, err := base64.StdEncoding.DecodeString(script); err != nil {
+		return base64.StdEncoding.EncodeToString([]byte(script))
+	}
+	return script
+}
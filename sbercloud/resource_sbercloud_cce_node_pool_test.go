@@ -2,16 +2,178 @@ package sbercloud
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
 	"github.com/huaweicloud/golangsdk/openstack/cce/v3/nodepools"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/nodes"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
 
+// TestCCENodePool_stubRollingUpdateResume interrupts a rolling update by a
+// failed node deletion and checks that the next update replaces the nodes left.
+func TestCCENodePool_stubRollingUpdateResume(t *testing.T) {
+	defer func(create, update, change time.Duration) {
+		cceNodePoolCreateDelay, cceNodePoolUpdateDelay, cceNodeChangeDelay = create, update, change
+	}(cceNodePoolCreateDelay, cceNodePoolUpdateDelay, cceNodeChangeDelay)
+	cceNodePoolCreateDelay, cceNodePoolUpdateDelay, cceNodeChangeDelay = 0, 0, 0
+
+	cluster := "/api/v3/projects/" + stubProjectID + "/clusters/stub-cluster"
+	stub := newAPIStub()
+	stub.objects[cluster] = map[string]interface{}{
+		"metadata": map[string]interface{}{"uid": "stub-cluster"},
+		"status":   map[string]interface{}{"phase": "Available"},
+	}
+	// The stub node pools create nodes from their node template up to their
+	// node count, deleting a node decreases the node count of its pool.
+	stub.afterCCE = func(method, path string, obj map[string]interface{}) {
+		switch {
+		case strings.HasPrefix(path, cluster+"/nodepools/") && method != http.MethodDelete:
+			testCCENodePoolStubSync(stub, cluster, obj)
+		case strings.HasPrefix(path, cluster+"/nodes/") && method == http.MethodDelete:
+			annotations := obj["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+			pool := stub.objects[cluster+"/nodepools/"+annotations[cceNodePoolIDAnnotation].(string)]
+			spec := pool["spec"].(map[string]interface{})
+			spec["initialNodeCount"] = spec["initialNodeCount"].(float64) - 1
+			testCCENodePoolStubSync(stub, cluster, pool)
+		}
+	}
+	deletes := 0
+	stub.failCCE = func(method, path string) int {
+		if method == http.MethodDelete && strings.HasPrefix(path, cluster+"/nodes/") {
+			deletes++
+			if deletes == 2 {
+				return http.StatusConflict
+			}
+		}
+		return 0
+	}
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	raw := map[string]interface{}{
+		"cluster_id":         "stub-cluster",
+		"name":               "test-pool",
+		"flavor_id":          "s6.large.2",
+		"availability_zone":  "ru-moscow-1a",
+		"key_pair":           "stub-key",
+		"initial_node_count": 2,
+		"root_volume": []interface{}{
+			map[string]interface{}{"size": 40, "volumetype": "SSD"},
+		},
+		"data_volumes": []interface{}{
+			map[string]interface{}{"size": 100, "volumetype": "SSD"},
+		},
+		"rolling_update": []interface{}{
+			map[string]interface{}{"max_surge": 1, "max_unavailable": 0},
+		},
+	}
+	r := ResourceCCENodePool()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if err := resourceCCENodePoolCreate(d, config); err != nil {
+		t.Fatalf("Error creating node pool: %s", err)
+	}
+	original := testCCENodePoolStubNodes(t, stub, cluster, d.Id(), "s6.large.2")
+	if len(original) != 2 {
+		t.Fatalf("Expected 2 nodes after the creation, got %v", original)
+	}
+
+	raw["flavor_id"] = "s6.xlarge.2"
+	d = stubUpdateData(t, r, d, raw, config)
+	err := resourceCCENodePoolUpdate(d, config)
+	if err == nil || !strings.Contains(err.Error(), "apply again") {
+		t.Fatalf("Expected the rolling update to be interrupted, got %v", err)
+	}
+	outdated := d.State().Attributes
+	if outdated["outdated_nodes.#"] != "1" || outdated["flavor_id"] != "s6.xlarge.2" {
+		t.Fatalf("Expected 1 outdated node and the new flavor in the state, got %v", outdated)
+	}
+
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("Error planning the update: %s", err)
+	}
+	if diff == nil || diff.Attributes["rolling_update_pending"] == nil || diff.Attributes["outdated_nodes.#"] != nil {
+		t.Fatalf("Expected only rolling_update_pending to force the update, got %v", diff)
+	}
+
+	d = stubUpdateData(t, r, d, raw, config)
+	if err := resourceCCENodePoolUpdate(d, config); err != nil {
+		t.Fatalf("Error resuming the rolling update: %s", err)
+	}
+	if len(d.Get("outdated_nodes").([]interface{})) != 0 || d.Get("rolling_update_pending").(bool) {
+		t.Fatalf("Expected no outdated nodes left, got %v", d.Get("outdated_nodes"))
+	}
+	if nodes := testCCENodePoolStubNodes(t, stub, cluster, d.Id(), "s6.large.2"); len(nodes) != 0 {
+		t.Fatalf("Expected the nodes of the previous flavor to be replaced, got %v", nodes)
+	}
+	if nodes := testCCENodePoolStubNodes(t, stub, cluster, d.Id(), "s6.xlarge.2"); len(nodes) != 2 {
+		t.Fatalf("Expected 2 nodes of the new flavor, got %v", nodes)
+	}
+}
+
+// testCCENodePoolStubSync creates the nodes of the stub node pool up to its
+// node count and updates its status.
+func testCCENodePoolStubSync(stub *apiStub, cluster string, pool map[string]interface{}) {
+	poolID := pool["metadata"].(map[string]interface{})["uid"].(string)
+	spec := pool["spec"].(map[string]interface{})
+	flavor := spec["nodeTemplate"].(map[string]interface{})["flavor"]
+
+	count := 0
+	for key, obj := range stub.objects {
+		if strings.HasPrefix(key, cluster+"/nodes/") && testCCENodePoolStubNodePool(obj) == poolID {
+			count++
+		}
+	}
+	for ; count < int(spec["initialNodeCount"].(float64)); count++ {
+		stub.counter++
+		id := fmt.Sprintf("node-%d", stub.counter)
+		stub.objects[cluster+"/nodes/"+id] = map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"uid":         id,
+				"name":        id,
+				"annotations": map[string]interface{}{cceNodePoolIDAnnotation: poolID},
+			},
+			"spec":   map[string]interface{}{"flavor": flavor},
+			"status": map[string]interface{}{"phase": "Active"},
+		}
+	}
+	pool["status"] = map[string]interface{}{"currentNode": count}
+}
+
+func testCCENodePoolStubNodePool(node map[string]interface{}) string {
+	metadata, _ := node["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	poolID, _ := annotations[cceNodePoolIDAnnotation].(string)
+	return poolID
+}
+
+// testCCENodePoolStubNodes returns the IDs of the nodes of the pool with the
+// given flavor.
+func testCCENodePoolStubNodes(t *testing.T, stub *apiStub, cluster, poolID, flavor string) []string {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	var ids []string
+	for key, obj := range stub.objects {
+		if !strings.HasPrefix(key, cluster+"/nodes/") || testCCENodePoolStubNodePool(obj) != poolID {
+			continue
+		}
+		if obj["spec"].(map[string]interface{})["flavor"] == flavor {
+			ids = append(ids, strings.TrimPrefix(key, cluster+"/nodes/"))
+		}
+	}
+	return ids
+}
+
 func TestAccCCENodePool_basic(t *testing.T) {
 	var nodePool nodepools.NodePool
 
@@ -22,7 +184,10 @@ func TestAccCCENodePool_basic(t *testing.T) {
 	clusterName := "sbercloud_cce_cluster.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckProjectID(t)
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCCENodePoolDestroy,
 		Steps: []resource.TestStep{
@@ -49,6 +214,7 @@ func TestAccCCENodePool_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "max_node_count", "9"),
 					resource.TestCheckResourceAttr(resourceName, "scale_down_cooldown_time", "100"),
 					resource.TestCheckResourceAttr(resourceName, "priority", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "current_node_count"),
 				),
 			},
 			{
@@ -100,6 +266,79 @@ func TestAccCCENodePool_tags(t *testing.T) {
 	})
 }
 
+func TestAccCCENodePool_rollingUpdate(t *testing.T) {
+	var nodePool, updated nodepools.NodePool
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_node_pool.test"
+	clusterName := "sbercloud_cce_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCENodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENodePool_rollingUpdate(rName, "s6.large.2", 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolExists(resourceName, clusterName, &nodePool),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", "s6.large.2"),
+					resource.TestCheckResourceAttr(resourceName, "outdated_nodes.#", "0"),
+				),
+			},
+			{
+				Config: testAccCCENodePool_rollingUpdate(rName, "s6.xlarge.2", 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolExists(resourceName, clusterName, &updated),
+					testAccCheckCCENodePoolNotRecreated(&nodePool, &updated),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", "s6.xlarge.2"),
+					resource.TestCheckResourceAttr(resourceName, "initial_node_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "outdated_nodes.#", "0"),
+				),
+			},
+			{
+				Config:      testAccCCENodePool_rollingUpdate(rName, "s6.large.2", 0, 0),
+				ExpectError: regexp.MustCompile("must be greater than 0"),
+			},
+		},
+	})
+}
+
+func TestAccCCENodePool_labelsAndTaints(t *testing.T) {
+	var nodePool, updated nodepools.NodePool
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_node_pool.test"
+	clusterName := "sbercloud_cce_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCENodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENodePool_labelsAndTaints(rName, "test_val", "NoSchedule"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolExists(resourceName, clusterName, &nodePool),
+					resource.TestCheckResourceAttr(resourceName, "labels.test_key", "test_val"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.effect", "NoSchedule"),
+					testAccCheckCCENodePoolNodesSynced(&nodePool, "test_key", "test_val", "NoSchedule"),
+				),
+			},
+			{
+				Config: testAccCCENodePool_labelsAndTaints(rName, "test_val_update", "PreferNoSchedule"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolExists(resourceName, clusterName, &updated),
+					testAccCheckCCENodePoolNotRecreated(&nodePool, &updated),
+					resource.TestCheckResourceAttr(resourceName, "labels.test_key", "test_val_update"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.effect", "PreferNoSchedule"),
+					testAccCheckCCENodePoolNodesSynced(&updated, "test_key", "test_val_update", "PreferNoSchedule"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCCENodePoolDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	cceClient, err := config.CceV3Client(SBC_REGION_NAME)
@@ -188,6 +427,49 @@ func testAccCheckCCENodePoolExists(n string, cluster string, nodePool *nodepools
 	}
 }
 
+func testAccCheckCCENodePoolNotRecreated(before, after *nodepools.NodePool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.Metadata.Id != after.Metadata.Id {
+			return fmt.Errorf("Node pool was recreated: %s -> %s", before.Metadata.Id, after.Metadata.Id)
+		}
+		return nil
+	}
+}
+
+// testAccCheckCCENodePoolNodesSynced checks that every node of the node pool
+// has the label and the "test_key" taint with the given effect.
+func testAccCheckCCENodePoolNodesSynced(nodePool *nodepools.NodePool, key, value, effect string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cluster, ok := s.RootModule().Resources["sbercloud_cce_cluster.test"]
+		if !ok {
+			return fmt.Errorf("Cluster not found")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		cceClient, err := config.CceV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+		}
+
+		poolNodes, err := listCCENodePoolNodes(cceClient, cluster.Primary.ID, nodePool.Metadata.Id)
+		if err != nil {
+			return err
+		}
+		if len(poolNodes) == 0 {
+			return fmt.Errorf("Node pool %s has no nodes", nodePool.Metadata.Id)
+		}
+		for id, node := range poolNodes {
+			if node.Spec.K8sTags[key] != value {
+				return fmt.Errorf("Node %s has label %s=%q, expected %q", id, key, node.Spec.K8sTags[key], value)
+			}
+			if !cceTaintsContain(node.Spec.Taints, nodes.TaintSpec{Key: key, Effect: effect}) {
+				return fmt.Errorf("Node %s has no %s taint with effect %s", id, key, effect)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCCENodePool_Base(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -245,7 +527,31 @@ func testAccCCENodePool_update(rName, updateName string) string {
 	return fmt.Sprintf(`
 %s
 
+data "sbercloud_cce_addon_template" "autoscaler" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "autoscaler"
+}
+
+resource "sbercloud_cce_addon" "autoscaler" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  template_name = "autoscaler"
+  version       = data.sbercloud_cce_addon_template.autoscaler.version
+
+  values {
+    basic_json  = jsonencode(jsondecode(data.sbercloud_cce_addon_template.autoscaler.spec).basic)
+    custom_json = jsonencode(merge(
+      jsondecode(data.sbercloud_cce_addon_template.autoscaler.spec).parameters.custom,
+      {
+        cluster_id = sbercloud_cce_cluster.test.id
+        tenant_id  = "%s"
+      }
+    ))
+  }
+}
+
 resource "sbercloud_cce_node_pool" "test" {
+  depends_on = [sbercloud_cce_addon.autoscaler]
+
   cluster_id               = sbercloud_cce_cluster.test.id
   name                     = "%s"
   os                       = "EulerOS 2.5"
@@ -269,7 +575,7 @@ resource "sbercloud_cce_node_pool" "test" {
     volumetype = "SSD"
   }
 }
-`, testAccCCENodePool_Base(rName), updateName)
+`, testAccCCENodePool_Base(rName), SBC_PROJECT_ID, updateName)
 }
 
 func testAccCCENodePool_volume_extendParams(rName string) string {
@@ -389,3 +695,69 @@ resource "sbercloud_cce_node_pool" "test" {
 }
 `, testAccCCENodePool_Base(rName), rName)
 }
+
+func testAccCCENodePool_rollingUpdate(rName, flavor string, maxSurge, maxUnavailable int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_node_pool" "test" {
+  cluster_id         = sbercloud_cce_cluster.test.id
+  name               = "%s"
+  os                 = "EulerOS 2.5"
+  flavor_id          = "%s"
+  initial_node_count = 2
+  availability_zone  = data.sbercloud_availability_zones.test.names[0]
+  key_pair           = sbercloud_compute_keypair.test.name
+  type               = "vm"
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+
+  rolling_update {
+    max_surge       = %d
+    max_unavailable = %d
+  }
+}
+`, testAccCCENodePool_Base(rName), rName, flavor, maxSurge, maxUnavailable)
+}
+
+func testAccCCENodePool_labelsAndTaints(rName, labelValue, taintEffect string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_node_pool" "test" {
+  cluster_id         = sbercloud_cce_cluster.test.id
+  name               = "%s"
+  os                 = "EulerOS 2.5"
+  flavor_id          = "s6.large.2"
+  initial_node_count = 1
+  availability_zone  = data.sbercloud_availability_zones.test.names[0]
+  key_pair           = sbercloud_compute_keypair.test.name
+  type               = "vm"
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+
+  labels = {
+    test_key = "%s"
+  }
+  taints {
+    key    = "test_key"
+    value  = "test_value"
+    effect = "%s"
+  }
+}
+`, testAccCCENodePool_Base(rName), rName, labelValue, taintEffect)
+}
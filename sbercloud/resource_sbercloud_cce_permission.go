@@ -0,0 +1,258 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/clusters"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// cceClusterScopedRoles are the ClusterRoles which can only be granted on the
+// whole cluster.
+var cceClusterScopedRoles = []string{"cluster-admin"}
+
+// cceClusterPermission is an authorization of an IAM user or user group to a
+// Kubernetes ClusterRole. CCE binds the ClusterRole to the IAM subject in the
+// listed namespaces, or in the whole cluster when no namespace is listed.
+type cceClusterPermission struct {
+	Kind       string `json:"kind"`
+	ApiVersion string `json:"apiVersion"`
+	Metadata   struct {
+		UID               string `json:"uid,omitempty"`
+		CreationTimestamp string `json:"creationTimestamp,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		SubjectType string   `json:"subjectType"`
+		SubjectID   string   `json:"subjectID"`
+		ClusterRole string   `json:"clusterRole"`
+		Namespaces  []string `json:"namespaces,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase,omitempty"`
+	} `json:"status"`
+}
+
+func ResourceCCEPermissionV3() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCCEPermissionV3Create,
+		Read:   resourceCCEPermissionV3Read,
+		Update: resourceCCEPermissionV3Update,
+		Delete: resourceCCEPermissionV3Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceCCEPermissionV3Import,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_id", "group_id"},
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"cluster_role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"namespaces": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func buildCCEPermissionOpts(d *schema.ResourceData) (*cceClusterPermission, error) {
+	var opts cceClusterPermission
+	opts.Kind = "Permission"
+	opts.ApiVersion = "v3"
+	if v, ok := d.GetOk("user_id"); ok {
+		opts.Spec.SubjectType = "user"
+		opts.Spec.SubjectID = v.(string)
+	} else {
+		opts.Spec.SubjectType = "group"
+		opts.Spec.SubjectID = d.Get("group_id").(string)
+	}
+
+	opts.Spec.ClusterRole = d.Get("cluster_role").(string)
+	for _, ns := range d.Get("namespaces").(*schema.Set).List() {
+		opts.Spec.Namespaces = append(opts.Spec.Namespaces, ns.(string))
+	}
+
+	if len(opts.Spec.Namespaces) > 0 {
+		for _, role := range cceClusterScopedRoles {
+			if opts.Spec.ClusterRole == role {
+				return nil, fmt.Errorf("The ClusterRole %s can only be granted on the whole cluster, "+
+					"remove namespaces", role)
+			}
+		}
+	}
+	return &opts, nil
+}
+
+func cceClusterPermissionURL(client *golangsdk.ServiceClient, clusterID string, id ...string) string {
+	return client.ServiceURL(append([]string{"clusters", clusterID, "permissions"}, id...)...)
+}
+
+func resourceCCEPermissionV3Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	createOpts, err := buildCCEPermissionOpts(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(cceClusterPermissionURL(client, d.Get("cluster_id").(string)), createOpts, &rst.Body,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200, 201},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	if rst.Err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE permission: %s", rst.Err)
+	}
+
+	var permission cceClusterPermission
+	if err := rst.ExtractInto(&permission); err != nil {
+		return fmt.Errorf("Error extracting SberCloud CCE permission: %s", err)
+	}
+	if permission.Metadata.UID == "" {
+		return fmt.Errorf("Error creating SberCloud CCE permission: no ID in the response")
+	}
+
+	d.SetId(permission.Metadata.UID)
+
+	return resourceCCEPermissionV3Read(d, meta)
+}
+
+func resourceCCEPermissionV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.CceV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(cceClusterPermissionURL(client, d.Get("cluster_id").(string), d.Id()), &rst.Body,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	if rst.Err != nil {
+		return CheckDeleted(d, rst.Err, "Error retrieving SberCloud CCE permission")
+	}
+
+	var permission cceClusterPermission
+	if err := rst.ExtractInto(&permission); err != nil {
+		return fmt.Errorf("Error extracting SberCloud CCE permission: %s", err)
+	}
+
+	d.Set("region", region)
+	switch permission.Spec.SubjectType {
+	case "user":
+		d.Set("user_id", permission.Spec.SubjectID)
+	case "group":
+		d.Set("group_id", permission.Spec.SubjectID)
+	}
+	d.Set("cluster_role", permission.Spec.ClusterRole)
+	d.Set("namespaces", permission.Spec.Namespaces)
+	d.Set("created_at", permission.Metadata.CreationTimestamp)
+	d.Set("status", permission.Status.Phase)
+
+	return nil
+}
+
+func resourceCCEPermissionV3Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	updateOpts, err := buildCCEPermissionOpts(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+
+	_, err = client.Put(cceClusterPermissionURL(client, d.Get("cluster_id").(string), d.Id()), updateOpts, nil,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud CCE permission: %s", err)
+	}
+
+	return resourceCCEPermissionV3Read(d, meta)
+}
+
+func resourceCCEPermissionV3Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	_, err = client.Delete(cceClusterPermissionURL(client, d.Get("cluster_id").(string), d.Id()),
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200, 204},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud CCE permission")
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceCCEPermissionV3Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid format specified for CCE permission. Format must be <cluster id>/<permission id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("cluster_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,212 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/cce/v3/clusters"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestCCEPermissionV3_stub(t *testing.T) {
+	stub := newAPIStub()
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	r := ResourceCCEPermissionV3()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id":   "stub-cluster",
+		"user_id":      "0bd3a9d9d5001d3a1f0ec00bd2d8b1a5",
+		"cluster_role": "edit",
+		"namespaces":   []interface{}{"dev", "test"},
+	})
+
+	if err := resourceCCEPermissionV3Create(d, config); err != nil {
+		t.Fatalf("Error creating permission: %s", err)
+	}
+	path := fmt.Sprintf("/api/v3/projects/%s/clusters/stub-cluster/permissions/%s", stubProjectID, d.Id())
+	obj, ok := stub.object(path)
+	if !ok {
+		t.Fatalf("The permission was not created at %s", path)
+	}
+	spec := obj["spec"].(map[string]interface{})
+	if spec["subjectType"] != "user" || spec["subjectID"] != "0bd3a9d9d5001d3a1f0ec00bd2d8b1a5" {
+		t.Fatalf("Unexpected subject in %v", spec)
+	}
+	if v := d.Get("namespaces").(*schema.Set).Len(); v != 2 {
+		t.Fatalf("Expected 2 namespaces, got %d", v)
+	}
+
+	d.Set("cluster_role", "view")
+	if err := resourceCCEPermissionV3Update(d, config); err != nil {
+		t.Fatalf("Error updating permission: %s", err)
+	}
+	obj, _ = stub.object(path)
+	if role := obj["spec"].(map[string]interface{})["clusterRole"]; role != "view" {
+		t.Fatalf("Expected the cluster role view, got %v", role)
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("stub-cluster/" + d.Id())
+	if _, err := r.Importer.State(imported, config); err != nil {
+		t.Fatalf("Error importing permission: %s", err)
+	}
+	if err := resourceCCEPermissionV3Read(imported, config); err != nil {
+		t.Fatalf("Error reading imported permission: %s", err)
+	}
+	if imported.Get("user_id").(string) != d.Get("user_id").(string) || imported.Get("cluster_role").(string) != "view" {
+		t.Fatalf("Imported permission differs: %v", imported.State())
+	}
+
+	if err := resourceCCEPermissionV3Delete(d, config); err != nil {
+		t.Fatalf("Error deleting permission: %s", err)
+	}
+	if _, ok := stub.object(path); ok {
+		t.Fatal("The permission still exists")
+	}
+}
+
+func TestCCEPermissionV3_clusterScopedRole(t *testing.T) {
+	stub := newAPIStub()
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, ResourceCCEPermissionV3().Schema, map[string]interface{}{
+		"cluster_id":   "stub-cluster",
+		"group_id":     "1b1d6c2b6e0040f4a3c5d6e9b4c1a2f0",
+		"cluster_role": "cluster-admin",
+		"namespaces":   []interface{}{"dev"},
+	})
+
+	err := resourceCCEPermissionV3Create(d, config)
+	if err == nil || !regexp.MustCompile("whole cluster").MatchString(err.Error()) {
+		t.Fatalf("Expected an error for a namespaced cluster-admin, got %v", err)
+	}
+	if len(stub.hosts) != 0 {
+		t.Fatal("No request is expected for an invalid permission")
+	}
+}
+
+func TestAccCCEPermissionV3_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_permission.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEPermissionV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEPermissionV3_basic(rName, "edit"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEPermissionV3Exists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "group_id", "sbercloud_identity_group.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_role", "edit"),
+					resource.TestCheckResourceAttr(resourceName, "namespaces.#", "1"),
+				),
+			},
+			{
+				Config: testAccCCEPermissionV3_basic(rName, "view"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEPermissionV3Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cluster_role", "view"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCCEPermissionV3ImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccCCEPermissionV3Get(rs *terraform.ResourceState) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CceV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE client: %s", err)
+	}
+
+	_, err = client.Get(cceClusterPermissionURL(client, rs.Primary.Attributes["cluster_id"], rs.Primary.ID), nil,
+		&golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+	return err
+}
+
+func testAccCheckCCEPermissionV3Destroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cce_permission" {
+			continue
+		}
+
+		err := testAccCCEPermissionV3Get(rs)
+		if err == nil {
+			return fmt.Errorf("CCE permission %s still exists", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCCEPermissionV3Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return testAccCCEPermissionV3Get(rs)
+	}
+}
+
+func testAccCCEPermissionV3ImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["cluster_id"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCCEPermissionV3_basic(rName, clusterRole string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_identity_group" "test" {
+  name = "%s"
+}
+
+resource "sbercloud_cce_namespace" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "%s"
+}
+
+resource "sbercloud_cce_permission" "test" {
+  cluster_id   = sbercloud_cce_cluster.test.id
+  group_id     = sbercloud_identity_group.test.id
+  cluster_role = "%s"
+  namespaces   = [sbercloud_cce_namespace.test.name]
+}
+`, testAccCCEClusterV3_basic(rName), rName, rName, clusterRole)
+}
@@ -0,0 +1,1587 @@
+package sbercloud
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/blockstorage/extensions/volumeactions"
+	"github.com/huaweicloud/golangsdk/openstack/blockstorage/v2/volumes"
+	"github.com/huaweicloud/golangsdk/openstack/bss/v2/orders"
+	"github.com/huaweicloud/golangsdk/openstack/common/tags"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/bootfromvolume"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/keypairs"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/schedulerhints"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/secgroups"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/flavors"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/images"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/servers"
+	"github.com/huaweicloud/golangsdk/openstack/ecs/v1/block_devices"
+	"github.com/huaweicloud/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/huaweicloud/golangsdk/openstack/ecs/v1/powers"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/subnets"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/security/groups"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var powerActionMap = map[string]string{
+	"ON":     "os-start",
+	"OFF":    "os-stop",
+	"REBOOT": "reboot",
+}
+
+func ResourceComputeInstanceV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeInstanceV2Create,
+		Read:   resourceComputeInstanceV2Read,
+		Update: resourceComputeInstanceV2Update,
+		Delete: resourceComputeInstanceV2Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeInstanceV2ImportState,
+		},
+
+		CustomizeDiff: resourceComputeInstanceV2CustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// image_id and image_name are ForceNew unless image_update_strategy is "rebuild",
+			// see resourceComputeInstanceV2CustomizeDiff.
+			"image_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"image_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"image_update_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "replace",
+				ValidateFunc: validation.StringInSlice([]string{
+					"replace", "rebuild",
+				}, false),
+			},
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"flavor_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"resize_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "stop_by_ecs",
+				ValidateFunc: validation.StringInSlice([]string{
+					"stop_by_ecs", "stop_by_provider",
+				}, false),
+			},
+			"admin_pass": {
+				Type:      schema.TypeString,
+				Sensitive: true,
+				Optional:  true,
+			},
+			"key_pair": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"security_groups": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"security_group_ids"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+			},
+			"security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"network": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 12,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"fixed_ip_v4": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"fixed_ip_v6": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"mac": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"access_network": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"system_disk_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"SAS", "SSD", "GPSSD", "ESSD", "SATA",
+				}, true),
+			},
+			"system_disk_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"data_disks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 23,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"scheduler_hints": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"fault_domain": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"tenancy": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"deh_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+				Set: resourceComputeSchedulerHintsHash,
+			},
+			"user_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				// just stash the hash for state & diff comparisons
+				StateFunc: func(v interface{}) string {
+					switch v.(type) {
+					case string:
+						hash := sha1.Sum([]byte(v.(string)))
+						return hex.EncodeToString(hash[:])
+					default:
+						return ""
+					}
+				},
+			},
+			"stop_before_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"delete_disks_on_termination": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// charge info: charging_mode, period_unit, period, auto_renew
+			"charging_mode": schemeChargingMode(nil),
+			"period_unit":   schemaPeriodUnit(nil),
+			"period":        schemaPeriod(nil),
+			"auto_renew":    schemaAutoRenew(nil),
+
+			"user_id": { // required if in prePaid charging mode with key_pair.
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"agency_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: utils.ValidateECSTagValue,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+			},
+			"power_action": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// If you want to support more actions, please update powerActionMap simultaneously.
+				ValidateFunc: validation.StringInSlice([]string{
+					"ON", "OFF", "REBOOT", "FORCE-OFF", "FORCE-REBOOT",
+				}, false),
+			},
+			"volume_attached": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"boot_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pci_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"system_disk_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_ip_v4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_ip_v6": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Deprecated
+			"metadata": {
+				Type:       schema.TypeMap,
+				Optional:   true,
+				Deprecated: "use tags instead",
+				Elem:       &schema.Schema{Type: schema.TypeString},
+			},
+			"block_device": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"system_disk_type", "system_disk_size", "data_disks"},
+				Deprecated:    "use system_disk_type, system_disk_size, data_disks instead",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"uuid": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"volume_size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"destination_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"boot_index": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"delete_on_termination": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+						"guest_format": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud compute client: %s", err)
+	}
+	ecsClient, err := config.ComputeV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1 client: %s", err)
+	}
+	ecsV11Client, err := config.ComputeV11Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1.1 client: %s", err)
+	}
+	vpcClient, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+	sgClient, err := config.NetworkingV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	if err := checkBlockDeviceConfig(d); err != nil {
+		return err
+	}
+
+	imageID, err := getImageIDFromConfig(computeClient, d)
+	if err != nil {
+		return err
+	}
+
+	flavorID, err := getFlavorID(computeClient, d)
+	if err != nil {
+		return err
+	}
+
+	// The ECS API only creates the disks from system_disk_type, system_disk_size and
+	// data_disks, the servers with block_device are created through the nova API.
+	if _, ok := d.GetOk("block_device"); ok {
+		if err := createComputeInstanceWithBlockDevices(d, computeClient, ecsClient, imageID, flavorID); err != nil {
+			return err
+		}
+	} else {
+		vpcID, err := getVpcID(vpcClient, d)
+		if err != nil {
+			return err
+		}
+
+		secGroups, err := resourceInstanceSecGroupIdsV1(sgClient, d)
+		if err != nil {
+			return err
+		}
+
+		createOpts := &cloudservers.CreateOpts{
+			Name:             d.Get("name").(string),
+			ImageRef:         imageID,
+			FlavorRef:        flavorID,
+			KeyName:          d.Get("key_pair").(string),
+			VpcId:            vpcID,
+			SecurityGroups:   secGroups,
+			AvailabilityZone: d.Get("availability_zone").(string),
+			Nics:             resourceInstanceNicsV1(d),
+			RootVolume:       resourceInstanceRootVolumeV1(d),
+			DataVolumes:      resourceInstanceDataVolumesV1(d),
+			UserData:         []byte(d.Get("user_data").(string)),
+		}
+
+		var extendParam cloudservers.ServerExtendParam
+		if d.Get("charging_mode") == "prePaid" {
+			if err := validatePrePaidChargeInfo(d); err != nil {
+				return err
+			}
+
+			extendParam.ChargingMode = d.Get("charging_mode").(string)
+			extendParam.PeriodType = d.Get("period_unit").(string)
+			extendParam.PeriodNum = d.Get("period").(int)
+			extendParam.IsAutoPay = "true"
+			extendParam.IsAutoRenew = d.Get("auto_renew").(string)
+		}
+
+		if epsID := GetEnterpriseProjectID(d, config); epsID != "" {
+			extendParam.EnterpriseProjectId = epsID
+		}
+		if extendParam != (cloudservers.ServerExtendParam{}) {
+			createOpts.ExtendParam = &extendParam
+		}
+
+		var metadata cloudservers.MetaData
+		if v, ok := d.GetOk("user_id"); ok {
+			metadata.OpSvcUserId = v.(string)
+		}
+		if v, ok := d.GetOk("agency_name"); ok {
+			metadata.AgencyName = v.(string)
+		}
+		if metadata != (cloudservers.MetaData{}) {
+			createOpts.MetaData = &metadata
+		}
+
+		schedulerHintsRaw := d.Get("scheduler_hints").(*schema.Set).List()
+		if len(schedulerHintsRaw) > 0 {
+			log.Printf("[DEBUG] schedulerhints: %+v", schedulerHintsRaw)
+			schedulerHints := resourceInstanceSchedulerHintsV1(schedulerHintsRaw[0].(map[string]interface{}))
+			createOpts.SchedulerHints = &schedulerHints
+		}
+
+		log.Printf("[DEBUG] ECS Create Options: %#v", createOpts)
+		// Add password here so it wouldn't go in the above log entry
+		createOpts.AdminPass = d.Get("admin_pass").(string)
+
+		var jobID string
+		if d.Get("charging_mode") == "prePaid" {
+			n, err := cloudservers.CreatePrePaid(ecsV11Client, createOpts).ExtractOrderResponse()
+			if err != nil {
+				return fmt.Errorf("Error creating SberCloud server: %s", err)
+			}
+			jobID = n.JobID
+		} else {
+			n, err := cloudservers.Create(ecsV11Client, createOpts).ExtractJobResponse()
+			if err != nil {
+				return fmt.Errorf("Error creating SberCloud server: %s", err)
+			}
+			jobID = n.JobID
+		}
+
+		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), jobID); err != nil {
+			return err
+		}
+
+		entity, err := cloudservers.GetJobEntity(ecsClient, jobID, "server_id")
+		if err != nil {
+			return err
+		}
+		d.SetId(entity.(string))
+	}
+
+	if tagRaw := d.Get("tags").(map[string]interface{}); len(tagRaw) > 0 {
+		taglist := utils.ExpandResourceTags(tagRaw)
+		if tagErr := tags.Create(ecsClient, "cloudservers", d.Id(), taglist).ExtractErr(); tagErr != nil {
+			log.Printf("[WARN] Error setting tags of instance %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	// the ECS API only accepts the predefined metadata on creation
+	if metadata := d.Get("metadata").(map[string]interface{}); len(metadata) > 0 {
+		if err := updateComputeInstanceMetadata(computeClient, d.Id(), nil, metadata); err != nil {
+			return err
+		}
+	}
+
+	// Create an instance in the shutdown state.
+	if action, ok := d.GetOk("power_action"); ok {
+		action := action.(string)
+		if action == "OFF" || action == "FORCE-OFF" {
+			if err = doPowerAction(ecsClient, d, action); err != nil {
+				return fmt.Errorf("Doing power action (%s) for instance (%s) failed: %s", action, d.Id(), err)
+			}
+		} else {
+			log.Printf("[WARN] The power action (%s) is invalid after instance created", action)
+		}
+	}
+
+	return resourceComputeInstanceV2Read(d, meta)
+}
+
+func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud compute client: %s", err)
+	}
+	ecsClient, err := config.ComputeV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1 client: %s", err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud EVS client: %s", err)
+	}
+
+	server, err := cloudservers.Get(ecsClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "compute instance")
+	}
+	if server.Status == "DELETED" || server.Status == "SOFT_DELETED" {
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[DEBUG] Retrieved compute instance %s: %+v", d.Id(), server)
+	d.Set("region", region)
+	d.Set("enterprise_project_id", server.EnterpriseProjectID)
+	d.Set("availability_zone", server.AvailabilityZone)
+	d.Set("name", server.Name)
+	d.Set("status", server.Status)
+	d.Set("agency_name", server.Metadata.AgencyName)
+
+	switch server.Metadata.ChargingMode {
+	case "0":
+		d.Set("charging_mode", "postPaid")
+	case "1":
+		d.Set("charging_mode", "prePaid")
+	}
+
+	d.Set("flavor_id", server.Flavor.ID)
+	d.Set("flavor_name", server.Flavor.Name)
+
+	// Set the instance's image information appropriately
+	if err := setImageInformation(d, computeClient, server.Image.ID); err != nil {
+		return err
+	}
+
+	if server.KeyName != "" {
+		d.Set("key_pair", server.KeyName)
+	}
+	if eip := computePublicIP(server); eip != "" {
+		d.Set("public_ip", eip)
+	}
+
+	// Get the instance network and address information
+	networks, err := flattenInstanceNetworks(d, meta, server)
+	if err != nil {
+		return err
+	}
+	// Determine the best IPv4 and IPv6 addresses to access the instance with
+	hostv4, hostv6 := getInstanceAccessAddresses(networks)
+	if server.AccessIPv4 != "" {
+		hostv4 = server.AccessIPv4
+	}
+	if server.AccessIPv6 != "" {
+		hostv6 = server.AccessIPv6
+	}
+
+	d.Set("network", networks)
+	d.Set("access_ip_v4", hostv4)
+	d.Set("access_ip_v6", hostv6)
+
+	// Determine the best IP address to use for SSH connectivity.
+	// Prefer IPv4 over IPv6.
+	var preferredSSHAddress string
+	if hostv4 != "" {
+		preferredSSHAddress = hostv4
+	} else if hostv6 != "" {
+		preferredSSHAddress = hostv6
+	}
+
+	if preferredSSHAddress != "" {
+		d.SetConnInfo(map[string]string{
+			"type": "ssh",
+			"host": preferredSSHAddress,
+		})
+	}
+
+	secGrpNames := make([]string, len(server.SecurityGroups))
+	secGrpIDs := make([]string, len(server.SecurityGroups))
+	for i, sg := range server.SecurityGroups {
+		secGrpNames[i] = sg.Name
+		secGrpIDs[i] = sg.ID
+	}
+	d.Set("security_groups", secGrpNames)
+	d.Set("security_group_ids", secGrpIDs)
+
+	if len(server.VolumeAttached) > 0 {
+		bds := make([]map[string]interface{}, len(server.VolumeAttached))
+		for i, b := range server.VolumeAttached {
+			// retrieve volume `size` and `type`
+			volumeInfo, err := volumes.Get(blockStorageClient, b.ID).Extract()
+			if err != nil {
+				return err
+			}
+			log.Printf("[DEBUG] Retrieved volume %s: %#v", b.ID, volumeInfo)
+
+			// retrieve volume `pci_address`
+			va, err := block_devices.Get(ecsClient, d.Id(), b.ID).Extract()
+			if err != nil {
+				return err
+			}
+			log.Printf("[DEBUG] Retrieved block device %s: %#v", b.ID, va)
+
+			bds[i] = map[string]interface{}{
+				"volume_id":   b.ID,
+				"size":        volumeInfo.Size,
+				"type":        volumeInfo.VolumeType,
+				"boot_index":  va.BootIndex,
+				"pci_address": va.PciAddress,
+			}
+
+			if va.BootIndex == 0 {
+				d.Set("system_disk_id", b.ID)
+				d.Set("system_disk_size", volumeInfo.Size)
+				d.Set("system_disk_type", volumeInfo.VolumeType)
+			}
+		}
+		d.Set("volume_attached", bds)
+	}
+
+	osHints := server.OsSchedulerHints
+	if len(osHints.Group) > 0 {
+		schedulerHints := make([]map[string]interface{}, len(osHints.Group))
+		for i, v := range osHints.Group {
+			schedulerHints[i] = map[string]interface{}{
+				"group": v,
+			}
+		}
+		d.Set("scheduler_hints", schedulerHints)
+	}
+
+	if resourceTags, err := tags.Get(ecsClient, "cloudservers", d.Id()).Extract(); err == nil {
+		tagmap := utils.TagsToMap(resourceTags.Tags)
+		if err := d.Set("tags", tagmap); err != nil {
+			return fmt.Errorf("Error saving tags to state for compute instance (%s): %s", d.Id(), err)
+		}
+	} else {
+		log.Printf("[WARN] Error fetching tags of compute instance (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud compute client: %s", err)
+	}
+	ecsClient, err := config.ComputeV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1 client: %s", err)
+	}
+
+	if d.HasChange("name") {
+		updateOpts := servers.UpdateOpts{
+			Name: d.Get("name").(string),
+		}
+		if _, err := servers.Update(computeClient, d.Id(), updateOpts).Extract(); err != nil {
+			return fmt.Errorf("Error updating SberCloud server: %s", err)
+		}
+	}
+
+	if d.HasChange("metadata") {
+		oldMetadata, newMetadata := d.GetChange("metadata")
+		err := updateComputeInstanceMetadata(computeClient, d.Id(), oldMetadata.(map[string]interface{}),
+			newMetadata.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("security_group_ids", "security_groups") {
+		var oldSGRaw, newSGRaw interface{}
+		if d.HasChange("security_group_ids") {
+			oldSGRaw, newSGRaw = d.GetChange("security_group_ids")
+		} else {
+			oldSGRaw, newSGRaw = d.GetChange("security_groups")
+		}
+		oldSGSet := oldSGRaw.(*schema.Set)
+		newSGSet := newSGRaw.(*schema.Set)
+		secgroupsToAdd := newSGSet.Difference(oldSGSet)
+		secgroupsToRemove := oldSGSet.Difference(newSGSet)
+		log.Printf("[DEBUG] Security groups to add: %v", secgroupsToAdd)
+		log.Printf("[DEBUG] Security groups to remove: %v", secgroupsToRemove)
+
+		for _, g := range secgroupsToRemove.List() {
+			err := secgroups.RemoveServer(computeClient, d.Id(), g.(string)).ExtractErr()
+			if err != nil && err.Error() != "EOF" {
+				if _, ok := err.(golangsdk.ErrDefault404); ok {
+					continue
+				}
+				return fmt.Errorf("Error removing security group (%s) from SberCloud server (%s): %s", g, d.Id(), err)
+			}
+			log.Printf("[DEBUG] Removed security group (%s) from instance (%s)", g, d.Id())
+		}
+
+		for _, g := range secgroupsToAdd.List() {
+			err := secgroups.AddServer(computeClient, d.Id(), g.(string)).ExtractErr()
+			if err != nil && err.Error() != "EOF" {
+				return fmt.Errorf("Error adding security group (%s) to SberCloud server (%s): %s", g, d.Id(), err)
+			}
+			log.Printf("[DEBUG] Added security group (%s) to instance (%s)", g, d.Id())
+		}
+	}
+
+	if d.HasChange("admin_pass") {
+		if newPwd, ok := d.Get("admin_pass").(string); ok {
+			err := servers.ChangeAdminPassword(computeClient, d.Id(), newPwd).ExtractErr()
+			if err != nil {
+				return fmt.Errorf("Error changing admin password of SberCloud server (%s): %s", d.Id(), err)
+			}
+		}
+	}
+
+	if d.HasChanges("flavor_id", "flavor_name") {
+		var newFlavorID string
+		if d.HasChange("flavor_id") {
+			newFlavorID = d.Get("flavor_id").(string)
+		} else {
+			newFlavorID, err = flavors.IDFromName(computeClient, d.Get("flavor_name").(string))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := resizeComputeInstance(d, config, ecsClient, newFlavorID); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("image_id", "image_name") {
+		var newImageID string
+		if d.HasChange("image_id") {
+			newImageID = d.Get("image_id").(string)
+		} else {
+			newImageID, err = images.IDFromName(computeClient, d.Get("image_name").(string))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := changeComputeInstanceOS(d, ecsClient, newImageID); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("tags") {
+		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id())
+		if tagErr != nil {
+			return fmt.Errorf("Error updating tags of instance %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	if d.HasChange("system_disk_size") {
+		blockStorageClient, err := config.BlockStorageV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud block storage client: %s", err)
+		}
+
+		systemDiskID := d.Get("system_disk_id").(string)
+		extendOpts := volumeactions.ExtendSizeOpts{
+			NewSize: d.Get("system_disk_size").(int),
+		}
+		err = volumeactions.ExtendSize(blockStorageClient, systemDiskID, extendOpts).ExtractErr()
+		if err != nil {
+			return fmt.Errorf("Error extending sbercloud_compute_instance system disk %s size: %s", systemDiskID, err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"extending"},
+			Target:     []string{"available", "in-use"},
+			Refresh:    huaweicloud.VolumeV2StateRefreshFunc(blockStorageClient, systemDiskID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		if _, err = stateConf.WaitForState(); err != nil {
+			return fmt.Errorf(
+				"Error waiting for sbercloud_compute_instance system disk %s to become ready: %s", systemDiskID, err)
+		}
+	}
+
+	// The instance power status update needs to be done at the end
+	if d.HasChange("power_action") {
+		action := d.Get("power_action").(string)
+		if err = doPowerAction(ecsClient, d, action); err != nil {
+			return fmt.Errorf("Doing power action (%s) for instance (%s) failed: %s", action, d.Id(), err)
+		}
+	}
+
+	return resourceComputeInstanceV2Read(d, meta)
+}
+
+func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1 client: %s", err)
+	}
+
+	if d.Get("stop_before_destroy").(bool) {
+		// If the instance doesn't stop in time, it will be destroyed anyway.
+		if err := doPowerAction(ecsClient, d, "OFF"); err != nil {
+			log.Printf("[WARN] Error stopping SberCloud instance: %s", err)
+		}
+	}
+
+	if d.Get("charging_mode") == "prePaid" {
+		if err := UnsubscribePrePaidResource(d, config, []string{d.Id()}); err != nil {
+			return fmt.Errorf("Error unsubscribe SberCloud server: %s", err)
+		}
+	} else {
+		deleteOpts := cloudservers.DeleteOpts{
+			Servers: []cloudservers.Server{
+				{Id: d.Id()},
+			},
+			DeleteVolume: d.Get("delete_disks_on_termination").(bool),
+		}
+
+		n, err := cloudservers.Delete(ecsClient, deleteOpts).ExtractJobResponse()
+		if err != nil {
+			return fmt.Errorf("Error deleting SberCloud server: %s", err)
+		}
+
+		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutDelete)/time.Second), n.JobID); err != nil {
+			return err
+		}
+	}
+
+	// Instance may still exist after Order/Job succeed.
+	pending := []string{"ACTIVE", "SHUTOFF"}
+	target := []string{"DELETED", "SOFT_DELETED"}
+	if err := waitForComputeInstanceState(ecsClient, d.Id(), pending, target, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeInstanceV2ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating SberCloud ECS v1 client: %s", err)
+	}
+
+	server, err := cloudservers.Get(ecsClient, d.Id()).Extract()
+	if err != nil {
+		return nil, CheckDeleted(d, err, "compute instance")
+	}
+
+	allInstanceNics, err := getInstanceAddresses(d, meta, server)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching networks of compute instance %s: %s", d.Id(), err)
+	}
+
+	networks := []map[string]interface{}{}
+	for _, nic := range allInstanceNics {
+		v := map[string]interface{}{
+			"uuid":        nic.NetworkID,
+			"port":        nic.PortID,
+			"fixed_ip_v4": nic.FixedIPv4,
+			"fixed_ip_v6": nic.FixedIPv6,
+			"mac":         nic.MAC,
+		}
+		networks = append(networks, v)
+	}
+
+	log.Printf("[DEBUG] flatten Instance Networks: %#v", networks)
+	d.Set("network", networks)
+	d.Set("resize_mode", "stop_by_ecs")
+	d.Set("image_update_strategy", "replace")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceComputeInstanceV2CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("image_update_strategy").(string) == "rebuild" {
+		return nil
+	}
+
+	for _, key := range []string{"image_id", "image_name"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type computeInstanceChangeOSOpts struct {
+	AdminPass string                           `json:"adminpass,omitempty"`
+	KeyName   string                           `json:"keyname,omitempty"`
+	UserID    string                           `json:"userid,omitempty"`
+	ImageID   string                           `json:"imageid" required:"true"`
+	IsAutoPay string                           `json:"isAutoPay,omitempty"`
+	Mode      string                           `json:"mode,omitempty"`
+	MetaData  *computeInstanceChangeOSMetaData `json:"metadata,omitempty"`
+}
+
+type computeInstanceChangeOSMetaData struct {
+	UserData string `json:"user_data,omitempty"`
+}
+
+// changeComputeInstanceOS reinstalls the system disk of the instance from another image.
+// The key pair or admin password and the user data of the instance are passed on to the
+// new OS, while the NICs, EIPs and data disks stay attached to the server.
+func changeComputeInstanceOS(d *schema.ResourceData, ecsClient *golangsdk.ServiceClient, imageID string) error {
+	serverID := d.Id()
+	server, err := cloudservers.Get(ecsClient, serverID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving SberCloud server (%s): %s", serverID, err)
+	}
+
+	changeOpts := computeInstanceChangeOSOpts{
+		KeyName: d.Get("key_pair").(string),
+		UserID:  d.Get("user_id").(string),
+		ImageID: imageID,
+		Mode:    "withStopServer",
+	}
+	if server.UserData != "" {
+		changeOpts.MetaData = &computeInstanceChangeOSMetaData{
+			UserData: server.UserData,
+		}
+	}
+	if d.Get("charging_mode").(string) == "prePaid" {
+		changeOpts.IsAutoPay = "true"
+	}
+	log.Printf("[DEBUG] Change OS options: %#v", changeOpts)
+	// Add password here so it wouldn't go in the above log entry
+	if changeOpts.KeyName == "" {
+		changeOpts.AdminPass = d.Get("admin_pass").(string)
+	}
+
+	reqBody, err := golangsdk.BuildRequestBody(changeOpts, "os-change")
+	if err != nil {
+		return err
+	}
+
+	// The change-OS API which supports cloud-init user data is only provided by ECS v2.
+	ecsV2Client := *ecsClient
+	ecsV2Client.ResourceBase = strings.Replace(ecsClient.ResourceBase, "/v1/", "/v2/", 1)
+
+	var job cloudservers.JobResponse
+	_, err = ecsV2Client.Post(ecsV2Client.ServiceURL("cloudservers", serverID, "changeos"), reqBody, &job,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		return fmt.Errorf("Error changing OS of SberCloud server (%s): %s", serverID, err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if err := cloudservers.WaitForJobSuccess(ecsClient, int(timeout/time.Second), job.JobID); err != nil {
+		return fmt.Errorf("Error waiting for OS of instance (%s) to be changed: %s", serverID, err)
+	}
+
+	pending := []string{"REBUILD", "REBOOT", "HARD_REBOOT"}
+	target := []string{"ACTIVE", "SHUTOFF"}
+	return waitForComputeInstanceState(ecsClient, serverID, pending, target, timeout)
+}
+
+// resizeComputeInstance changes the flavor of the instance through the ECS resize API,
+// which always stops a running instance. In "stop_by_provider" mode the instance is
+// stopped before the resize and started again afterwards if it was running; in
+// "stop_by_ecs" mode the resize is requested in the withStopServer mode, so ECS stops
+// and starts the instance around the flavor change itself.
+// Prepaid instances are resized through an order which is paid automatically.
+func resizeComputeInstance(d *schema.ResourceData, config *config.Config, ecsClient *golangsdk.ServiceClient,
+	flavorID string) error {
+	region := GetRegion(d, config)
+	ecsV11Client, err := config.ComputeV11Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud ECS v1.1 client: %s", err)
+	}
+
+	serverID := d.Id()
+	server, err := cloudservers.Get(ecsClient, serverID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving SberCloud server (%s): %s", serverID, err)
+	}
+	wasRunning := server.Status == "ACTIVE"
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	resizeOpts := &cloudservers.ResizeOpts{
+		FlavorRef: flavorID,
+	}
+	stopByProvider := d.Get("resize_mode").(string) == "stop_by_provider"
+	if stopByProvider {
+		if wasRunning {
+			if err := stopComputeInstance(ecsClient, d, timeout); err != nil {
+				return err
+			}
+		}
+	} else {
+		resizeOpts.Mode = "withStopServer"
+	}
+	if d.Get("charging_mode").(string) == "prePaid" {
+		resizeOpts.ExtendParam = &cloudservers.ResizeExtendParam{
+			AutoPay: "true",
+		}
+	}
+
+	log.Printf("[DEBUG] Resize configuration: %#v", resizeOpts)
+	var resp cloudservers.OrderResponse
+	result := cloudservers.Resize(ecsV11Client, resizeOpts, serverID)
+	if err := result.ExtractInto(&resp); err != nil {
+		return fmt.Errorf("Error resizing SberCloud server (%s): %s", serverID, err)
+	}
+
+	if resp.OrderID != "" {
+		bssV2Client, err := config.BssV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud bss V2 client: %s", err)
+		}
+		if err := orders.WaitForOrderSuccess(bssV2Client, int(timeout/time.Second), resp.OrderID); err != nil {
+			return fmt.Errorf("Error waiting for resize order (%s) of instance (%s) to be paid: %s",
+				resp.OrderID, serverID, err)
+		}
+	}
+	if resp.JobID != "" {
+		if err := cloudservers.WaitForJobSuccess(ecsClient, int(timeout/time.Second), resp.JobID); err != nil {
+			return fmt.Errorf("Error waiting for instance (%s) to be resized: %s", serverID, err)
+		}
+	}
+
+	pending := []string{"RESIZE", "VERIFY_RESIZE", "REBOOT", "HARD_REBOOT"}
+	target := []string{"ACTIVE", "SHUTOFF"}
+	if err := waitForComputeInstanceState(ecsClient, serverID, pending, target, timeout); err != nil {
+		return err
+	}
+
+	if stopByProvider && wasRunning && !strings.HasSuffix(d.Get("power_action").(string), "OFF") {
+		if err := doPowerAction(ecsClient, d, "ON"); err != nil {
+			return fmt.Errorf("Error starting instance (%s) after resize: %s", serverID, err)
+		}
+	}
+
+	return nil
+}
+
+// updateComputeInstanceMetadata deletes the metadata keys removed from the configuration
+// and sets the other ones through the nova API.
+func updateComputeInstanceMetadata(client *golangsdk.ServiceClient, serverID string,
+	oldMetadata, newMetadata map[string]interface{}) error {
+	for key := range oldMetadata {
+		if _, ok := newMetadata[key]; ok {
+			continue
+		}
+		if err := servers.DeleteMetadatum(client, serverID, key).ExtractErr(); err != nil {
+			return fmt.Errorf("Error deleting metadata (%s) from SberCloud server (%s): %s", key, serverID, err)
+		}
+	}
+
+	if len(newMetadata) == 0 {
+		return nil
+	}
+	metadataOpts := make(servers.MetadataOpts)
+	for k, v := range newMetadata {
+		metadataOpts[k] = v.(string)
+	}
+	if _, err := servers.UpdateMetadata(client, serverID, metadataOpts).Extract(); err != nil {
+		return fmt.Errorf("Error updating SberCloud server (%s) metadata: %s", serverID, err)
+	}
+	return nil
+}
+
+// stopComputeInstance stops the instance gracefully and falls back to a forced stop
+// if the guest OS does not shut down in time.
+func stopComputeInstance(client *golangsdk.ServiceClient, d *schema.ResourceData, timeout time.Duration) error {
+	if err := doPowerAction(client, d, "OFF"); err != nil {
+		log.Printf("[WARN] Error stopping instance (%s) gracefully, forcing it off: %s", d.Id(), err)
+		if err := doPowerAction(client, d, "FORCE-OFF"); err != nil {
+			return fmt.Errorf("Error stopping instance (%s): %s", d.Id(), err)
+		}
+	}
+
+	return waitForComputeInstanceState(client, d.Id(), []string{"ACTIVE"}, []string{"SHUTOFF"}, timeout)
+}
+
+func resourceInstanceSecGroupIdsV1(client *golangsdk.ServiceClient, d *schema.ResourceData) ([]cloudservers.SecurityGroup, error) {
+	if v, ok := d.GetOk("security_group_ids"); ok {
+		rawSecGroups := v.(*schema.Set).List()
+		secgroups := make([]cloudservers.SecurityGroup, len(rawSecGroups))
+		for i, raw := range rawSecGroups {
+			secgroups[i] = cloudservers.SecurityGroup{
+				ID: raw.(string),
+			}
+		}
+		return secgroups, nil
+	}
+
+	rawSecGroups := d.Get("security_groups").(*schema.Set).List()
+	secgroups := make([]cloudservers.SecurityGroup, len(rawSecGroups))
+	for i, raw := range rawSecGroups {
+		secID, err := groups.IDFromName(client, raw.(string))
+		if err != nil {
+			return secgroups, err
+		}
+		secgroups[i] = cloudservers.SecurityGroup{
+			ID: secID,
+		}
+	}
+	return secgroups, nil
+}
+
+func resourceInstanceNicsV1(d *schema.ResourceData) []cloudservers.Nic {
+	var nicRequests []cloudservers.Nic
+
+	networks := d.Get("network").([]interface{})
+	for _, v := range networks {
+		network := v.(map[string]interface{})
+		nicRequest := cloudservers.Nic{
+			SubnetId:  network["uuid"].(string),
+			IpAddress: network["fixed_ip_v4"].(string),
+		}
+
+		nicRequests = append(nicRequests, nicRequest)
+	}
+	return nicRequests
+}
+
+func resourceInstanceRootVolumeV1(d *schema.ResourceData) cloudservers.RootVolume {
+	diskType := d.Get("system_disk_type").(string)
+	if diskType == "" {
+		diskType = "GPSSD"
+	}
+	return cloudservers.RootVolume{
+		VolumeType: diskType,
+		Size:       d.Get("system_disk_size").(int),
+	}
+}
+
+func resourceInstanceDataVolumesV1(d *schema.ResourceData) []cloudservers.DataVolume {
+	var volRequests []cloudservers.DataVolume
+
+	vols := d.Get("data_disks").([]interface{})
+	for i := range vols {
+		vol := vols[i].(map[string]interface{})
+		volRequest := cloudservers.DataVolume{
+			VolumeType: vol["type"].(string),
+			Size:       vol["size"].(int),
+		}
+		if vol["snapshot_id"] != "" {
+			volRequest.Extendparam = &cloudservers.VolumeExtendParam{
+				SnapshotId: vol["snapshot_id"].(string),
+			}
+		}
+
+		volRequests = append(volRequests, volRequest)
+	}
+	return volRequests
+}
+
+func resourceInstanceSchedulerHintsV1(schedulerHintsRaw map[string]interface{}) cloudservers.SchedulerHints {
+	return cloudservers.SchedulerHints{
+		Group:           schedulerHintsRaw["group"].(string),
+		FaultDomain:     schedulerHintsRaw["fault_domain"].(string),
+		Tenancy:         schedulerHintsRaw["tenancy"].(string),
+		DedicatedHostID: schedulerHintsRaw["deh_id"].(string),
+	}
+}
+
+func getImageIDFromConfig(computeClient *golangsdk.ServiceClient, d *schema.ResourceData) (string, error) {
+	// A server booting from a block device only needs the image for an image/local
+	// block device.
+	if vL, ok := d.GetOk("block_device"); ok {
+		needImage := false
+		for _, v := range vL.([]interface{}) {
+			vM := v.(map[string]interface{})
+			if vM["source_type"] == "image" && vM["destination_type"] == "local" {
+				needImage = true
+			}
+		}
+		if !needImage {
+			return "", nil
+		}
+	}
+
+	if imageID := d.Get("image_id").(string); imageID != "" {
+		return imageID, nil
+	}
+
+	if imageName := d.Get("image_name").(string); imageName != "" {
+		return images.IDFromName(computeClient, imageName)
+	}
+
+	return "", fmt.Errorf("one of `image_id, image_name` must be specified")
+}
+
+// createComputeInstanceWithBlockDevices creates the server with block_device through
+// the nova API and waits for it to become active.
+func createComputeInstanceWithBlockDevices(d *schema.ResourceData, computeClient, ecsClient *golangsdk.ServiceClient,
+	imageID, flavorID string) error {
+	networks, err := expandInstanceNetworks(d)
+	if err != nil {
+		return err
+	}
+	blockDevices, err := resourceInstanceBlockDevicesV2(d.Get("block_device").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	var createOpts servers.CreateOptsBuilder
+	createOpts = &servers.CreateOpts{
+		Name:             d.Get("name").(string),
+		ImageRef:         imageID,
+		FlavorRef:        flavorID,
+		SecurityGroups:   utils.ExpandToStringList(d.Get("security_groups").(*schema.Set).List()),
+		AvailabilityZone: d.Get("availability_zone").(string),
+		Networks:         networks,
+		AdminPass:        d.Get("admin_pass").(string),
+		UserData:         []byte(d.Get("user_data").(string)),
+	}
+	if keyName := d.Get("key_pair").(string); keyName != "" {
+		createOpts = &keypairs.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			KeyName:           keyName,
+		}
+	}
+	createOpts = &bootfromvolume.CreateOptsExt{
+		CreateOptsBuilder: createOpts,
+		BlockDevice:       blockDevices,
+	}
+
+	schedulerHintsRaw := d.Get("scheduler_hints").(*schema.Set).List()
+	if len(schedulerHintsRaw) > 0 {
+		hints := schedulerHintsRaw[0].(map[string]interface{})
+		createOpts = &schedulerhints.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			SchedulerHints: schedulerhints.SchedulerHints{
+				Group:           hints["group"].(string),
+				Tenancy:         hints["tenancy"].(string),
+				DedicatedHostID: hints["deh_id"].(string),
+			},
+		}
+	}
+
+	log.Printf("[DEBUG] Nova Create Options: %#v", createOpts)
+	server, err := bootfromvolume.Create(computeClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud server: %s", err)
+	}
+	d.SetId(server.ID)
+
+	log.Printf("[DEBUG] Waiting for instance (%s) to become running", server.ID)
+	return waitForComputeInstanceState(ecsClient, server.ID, []string{"BUILD"}, []string{"ACTIVE"},
+		d.Timeout(schema.TimeoutCreate))
+}
+
+// expandInstanceNetworks returns the networks of the server for the nova API.
+func expandInstanceNetworks(d *schema.ResourceData) ([]servers.Network, error) {
+	var networks []servers.Network
+	for _, v := range d.Get("network").([]interface{}) {
+		nic := v.(map[string]interface{})
+		network := servers.Network{
+			UUID:    nic["uuid"].(string),
+			Port:    nic["port"].(string),
+			FixedIP: nic["fixed_ip_v4"].(string),
+		}
+		if network.UUID == "" && network.Port == "" {
+			return nil, fmt.Errorf("At least one of network.uuid or network.port must be set")
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func resourceInstanceBlockDevicesV2(bds []interface{}) ([]bootfromvolume.BlockDevice, error) {
+	blockDeviceOpts := make([]bootfromvolume.BlockDevice, len(bds))
+	for i, bd := range bds {
+		bdM := bd.(map[string]interface{})
+		blockDeviceOpts[i] = bootfromvolume.BlockDevice{
+			UUID:                bdM["uuid"].(string),
+			VolumeSize:          bdM["volume_size"].(int),
+			BootIndex:           bdM["boot_index"].(int),
+			DeleteOnTermination: bdM["delete_on_termination"].(bool),
+			GuestFormat:         bdM["guest_format"].(string),
+		}
+
+		sourceType := bdM["source_type"].(string)
+		switch sourceType {
+		case "blank":
+			blockDeviceOpts[i].SourceType = bootfromvolume.SourceBlank
+		case "image":
+			blockDeviceOpts[i].SourceType = bootfromvolume.SourceImage
+		case "snapshot":
+			blockDeviceOpts[i].SourceType = bootfromvolume.SourceSnapshot
+		case "volume":
+			blockDeviceOpts[i].SourceType = bootfromvolume.SourceVolume
+		default:
+			return nil, fmt.Errorf("unknown block device source type %s", sourceType)
+		}
+
+		destinationType := bdM["destination_type"].(string)
+		switch destinationType {
+		case "local":
+			blockDeviceOpts[i].DestinationType = bootfromvolume.DestinationLocal
+		case "volume":
+			blockDeviceOpts[i].DestinationType = bootfromvolume.DestinationVolume
+		default:
+			return nil, fmt.Errorf("unknown block device destination type %s", destinationType)
+		}
+	}
+
+	log.Printf("[DEBUG] Block Device Options: %+v", blockDeviceOpts)
+	return blockDeviceOpts, nil
+}
+
+func checkBlockDeviceConfig(d *schema.ResourceData) error {
+	if vL, ok := d.GetOk("block_device"); ok {
+		for _, v := range vL.([]interface{}) {
+			vM := v.(map[string]interface{})
+
+			if vM["source_type"] != "blank" && vM["uuid"] == "" {
+				return fmt.Errorf("You must specify a uuid for %s block device types", vM["source_type"])
+			}
+
+			if vM["source_type"] == "image" && vM["destination_type"] == "volume" {
+				if vM["volume_size"] == 0 {
+					return fmt.Errorf("You must specify a volume_size when creating a volume from an image")
+				}
+			}
+
+			if vM["source_type"] == "blank" && vM["destination_type"] == "local" {
+				if vM["volume_size"] == 0 {
+					return fmt.Errorf("You must specify a volume_size when creating a blank block device")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func setImageInformation(d *schema.ResourceData, computeClient *golangsdk.ServiceClient, imageID string) error {
+	if imageID == "" {
+		return nil
+	}
+
+	d.Set("image_id", imageID)
+	image, err := images.Get(computeClient, imageID).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			// If the image name can't be found, set the value to "Image not found".
+			// The most likely scenario is that the image no longer exists in the Image Service
+			// but the instance still has a record from when it existed.
+			d.Set("image_name", "Image not found")
+			return nil
+		}
+		return err
+	}
+	d.Set("image_name", image.Name)
+
+	return nil
+}
+
+// computePublicIP get the first floating address
+func computePublicIP(server *cloudservers.CloudServer) string {
+	for _, addresses := range server.Addresses {
+		for _, addr := range addresses {
+			if addr.Type == "floating" {
+				return addr.Addr
+			}
+		}
+	}
+
+	return ""
+}
+
+func getFlavorID(client *golangsdk.ServiceClient, d *schema.ResourceData) (string, error) {
+	if flavorID := d.Get("flavor_id").(string); flavorID != "" {
+		return flavorID, nil
+	}
+
+	if flavorName := d.Get("flavor_name").(string); flavorName != "" {
+		return flavors.IDFromName(client, flavorName)
+	}
+
+	return "", fmt.Errorf("one of `flavor_id, flavor_name` must be specified")
+}
+
+func getVpcID(client *golangsdk.ServiceClient, d *schema.ResourceData) (string, error) {
+	var networkID string
+
+	networks := d.Get("network").([]interface{})
+	if len(networks) > 0 {
+		// all networks belongs to one VPC
+		network := networks[0].(map[string]interface{})
+		networkID = network["uuid"].(string)
+	}
+
+	if networkID == "" {
+		return "", fmt.Errorf("Network ID should not be empty")
+	}
+
+	subnet, err := subnets.Get(client, networkID).Extract()
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving SberCloud Subnets: %s", err)
+	}
+
+	return subnet.VPC_ID, nil
+}
+
+func resourceComputeSchedulerHintsHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+
+	if m["group"] != nil {
+		buf.WriteString(fmt.Sprintf("%s-", m["group"].(string)))
+	}
+
+	if m["tenancy"] != nil {
+		buf.WriteString(fmt.Sprintf("%s-", m["tenancy"].(string)))
+	}
+
+	if m["deh_id"] != nil {
+		buf.WriteString(fmt.Sprintf("%s-", m["deh_id"].(string)))
+	}
+
+	return hashcode.String(buf.String())
+}
+
+func computeInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := cloudservers.Get(client, instanceID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return s, "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		if s.Status == "ERROR" {
+			return s, s.Status, fmt.Errorf("the instance (%s) is in ERROR state", instanceID)
+		}
+		return s, s.Status, nil
+	}
+}
+
+func waitForComputeInstanceState(client *golangsdk.ServiceClient, id string, pending, target []string,
+	timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      pending,
+		Target:       target,
+		Refresh:      computeInstanceStateRefreshFunc(client, id),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to become target state (%v): %s", id, target, err)
+	}
+	return nil
+}
+
+// doPowerAction is a method for instance power doing shutdown, startup and reboot actions.
+func doPowerAction(client *golangsdk.ServiceClient, d *schema.ResourceData, action string) error {
+	powerOpts := powers.PowerOpts{
+		Servers: []powers.ServerInfo{
+			{ID: d.Id()},
+		},
+	}
+	// In the reboot structure, Type is a required option.
+	// Since the type of power off and reboot is 'SOFT' by default, setting this value has solved the power structural
+	// compatibility problem between optional and required.
+	if action != "ON" {
+		powerOpts.Type = "SOFT"
+	}
+	if strings.HasPrefix(action, "FORCE-") {
+		powerOpts.Type = "HARD"
+		action = strings.TrimPrefix(action, "FORCE-")
+	}
+	op, ok := powerActionMap[action]
+	if !ok {
+		return fmt.Errorf("The powerMap does not contain option (%s)", action)
+	}
+	jobResp, err := powers.PowerAction(client, powerOpts, op).ExtractJobResponse()
+	if err != nil {
+		return fmt.Errorf("Doing power action (%s) for instance (%s) failed: %s", action, d.Id(), err)
+	}
+	// The time of the power on/off and reboot is usually between 15 and 35 seconds.
+	timeout := 3 * time.Minute
+	return cloudservers.WaitForJobSuccess(client, int(timeout/time.Second), jobResp.JobID)
+}
@@ -66,6 +66,60 @@ func TestAccComputeV2Instance_disks(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_blockDevice(t *testing.T) {
+	var instance servers.Server
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_blockDevice(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "block_device.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "volume_attached.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Instance_metadata(t *testing.T) {
+	var instance servers.Server
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_metadata(rName, "foo", "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_metadata(rName, "key", "value"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceNotRecreated(resourceName, &instance),
+					resource.TestCheckNoResourceAttr(resourceName, "metadata.foo"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.key", "value"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeV2Instance_tags(t *testing.T) {
 	var instance servers.Server
 
@@ -112,6 +166,84 @@ func TestAccComputeV2Instance_tags(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_resize(t *testing.T) {
+	var instance servers.Server
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_resize(rName, "stop_by_provider", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "flavor_id",
+						"data.sbercloud_compute_flavors.test", "ids.0"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_resize(rName, "stop_by_provider", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "flavor_id",
+						"data.sbercloud_compute_flavors.test", "ids.1"),
+					resource.TestCheckResourceAttr(resourceName, "resize_mode", "stop_by_provider"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_resize(rName, "stop_by_ecs", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "flavor_id",
+						"data.sbercloud_compute_flavors.test", "ids.0"),
+					resource.TestCheckResourceAttr(resourceName, "resize_mode", "stop_by_ecs"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Instance_rebuild(t *testing.T) {
+	var instance servers.Server
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_rebuild(rName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "image_id",
+						"data.sbercloud_images_image.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "network.0.fixed_ip_v4", "192.168.0.100"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_rebuild(rName, "rebuild"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceNotRecreated(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "image_id",
+						"data.sbercloud_images_image.rebuild", "id"),
+					resource.TestCheckResourceAttr(resourceName, "network.0.fixed_ip_v4", "192.168.0.100"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckComputeV2InstanceDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	computeClient, err := config.ComputeV2Client(SBC_REGION_NAME)
@@ -167,6 +299,21 @@ func testAccCheckComputeV2InstanceExists(n string, instance *servers.Server) res
 	}
 }
 
+func testAccCheckComputeV2InstanceNotRecreated(n string, instance *servers.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != instance.ID {
+			return fmt.Errorf("Instance was recreated: expected %s, got %s", instance.ID, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckComputeV2InstanceTags(
 	instance *servers.Server, k, v string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -254,6 +401,29 @@ resource "sbercloud_compute_instance" "test" {
 `, testAccCompute_data, rName)
 }
 
+func testAccComputeV2Instance_metadata(rName, key, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%s"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+
+  metadata = {
+    %s = "%s"
+  }
+}
+`, testAccCompute_data, rName, key, value)
+}
+
 func testAccComputeV2Instance_disks(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -280,6 +450,40 @@ resource "sbercloud_compute_instance" "test" {
 `, testAccCompute_data, rName)
 }
 
+func testAccComputeV2Instance_blockDevice(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%s"
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+
+  block_device {
+    uuid                  = data.sbercloud_images_image.test.id
+    source_type           = "image"
+    destination_type      = "volume"
+    volume_size           = 40
+    boot_index            = 0
+    delete_on_termination = true
+  }
+
+  block_device {
+    source_type           = "blank"
+    destination_type      = "volume"
+    volume_size           = 10
+    boot_index            = 1
+    delete_on_termination = true
+  }
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+`, testAccCompute_data, rName)
+}
+
 func testAccComputeV2Instance_prePaid(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -369,3 +573,51 @@ resource "sbercloud_compute_instance" "test" {
 }
 `, testAccCompute_data, rName)
 }
+
+func testAccComputeV2Instance_resize(rName, mode string, flavorIndex int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%s"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[%d]
+  resize_mode       = "%s"
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+`, testAccCompute_data, rName, flavorIndex, mode)
+}
+
+func testAccComputeV2Instance_rebuild(rName, image string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_images_image" "rebuild" {
+  name        = "Ubuntu 20.04 server 64bit"
+  most_recent = true
+}
+
+resource "sbercloud_compute_instance" "test" {
+  name                  = "%s"
+  image_id              = data.sbercloud_images_image.%s.id
+  image_update_strategy = "rebuild"
+  flavor_id             = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups       = ["default"]
+  availability_zone     = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type      = "SSD"
+  admin_pass            = "Test@123456"
+  user_data             = "#!/bin/bash\necho hello"
+
+  network {
+    uuid        = data.sbercloud_vpc_subnet.test.id
+    fixed_ip_v4 = "192.168.0.100"
+  }
+}
+`, testAccCompute_data, rName, image)
+}
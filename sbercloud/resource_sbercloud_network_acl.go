@@ -0,0 +1,833 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/subnets"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/firewall_groups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/policies"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/routerinsertion"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// networkACLRuleSchema returns the schema of the inline inbound and outbound
+// rules of the network ACL, which are evaluated by ascending priority. The
+// remote address group is the source of an inbound rule and the destination
+// of an outbound rule.
+func networkACLRuleSchema(conflict string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeSet,
+		Optional:      true,
+		Set:           resourceNetworkACLRuleHash,
+		ConflictsWith: []string{conflict},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"priority": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"action": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+				},
+				"protocol": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp", "any"}, false),
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"ip_version": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      4,
+					ValidateFunc: validation.IntInSlice([]int{4, 6}),
+				},
+				"source_ip_address": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"destination_ip_address": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"remote_address_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"source_port": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"destination_port": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func ResourceNetworkACL() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkACLCreate,
+		Read:   resourceNetworkACLRead,
+		Update: resourceNetworkACLUpdate,
+		Delete: resourceNetworkACLDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"inbound_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 10,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"outbound_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 10,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"inbound_rule":  networkACLRuleSchema("inbound_rules"),
+			"outbound_rule": networkACLRuleSchema("outbound_rules"),
+			"subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"inbound_policy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"outbound_policy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ports": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// networkACLRuleKey identifies an inline rule by its content, regardless of
+// its priority and ID, so that the unchanged rules are kept on reordering.
+func networkACLRuleKey(raw map[string]interface{}) string {
+	return strings.Join([]string{
+		raw["action"].(string),
+		raw["protocol"].(string),
+		raw["name"].(string),
+		raw["description"].(string),
+		fmt.Sprintf("%d", raw["ip_version"].(int)),
+		raw["source_ip_address"].(string),
+		raw["destination_ip_address"].(string),
+		raw["remote_address_group_id"].(string),
+		raw["source_port"].(string),
+		raw["destination_port"].(string),
+		fmt.Sprintf("%t", raw["enabled"].(bool)),
+	}, "|")
+}
+
+func resourceNetworkACLRuleHash(v interface{}) int {
+	raw := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%d|%s", raw["priority"].(int), networkACLRuleKey(raw)))
+}
+
+// sortedNetworkACLRules returns the inline rules ordered by priority.
+func sortedNetworkACLRules(set *schema.Set) ([]map[string]interface{}, error) {
+	sorted := make([]map[string]interface{}, 0, set.Len())
+	for _, v := range set.List() {
+		sorted = append(sorted, v.(map[string]interface{}))
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i]["priority"].(int) < sorted[j]["priority"].(int)
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i]["priority"] == sorted[i-1]["priority"] {
+			return nil, fmt.Errorf("Duplicate priority %d of the network ACL rules", sorted[i]["priority"])
+		}
+	}
+	return sorted, nil
+}
+
+// networkACLRemoteIPKey returns the IP address argument of an inline rule
+// which is replaced by the remote address group in the given direction.
+func networkACLRemoteIPKey(direction string) string {
+	if direction == "inbound" {
+		return "source_ip_address"
+	}
+	return "destination_ip_address"
+}
+
+// checkNetworkACLInlineRules checks that the remote address group and the
+// remote IP address are not both set on an inline rule.
+func checkNetworkACLInlineRules(direction string, inlineRules []map[string]interface{}) error {
+	ipKey := networkACLRemoteIPKey(direction)
+	for _, raw := range inlineRules {
+		if raw["remote_address_group_id"].(string) != "" && raw[ipKey].(string) != "" {
+			return fmt.Errorf("The %s rule with priority %d sets both remote_address_group_id and %s",
+				direction, raw["priority"], ipKey)
+		}
+	}
+	return nil
+}
+
+func createNetworkACLInlineRule(client *golangsdk.ServiceClient, direction string,
+	raw map[string]interface{}) (string, error) {
+	enabled := raw["enabled"].(bool)
+	createOpts := networkACLRuleCreateOpts{
+		CreateOpts: rules.CreateOpts{
+			Name:                 raw["name"].(string),
+			Description:          raw["description"].(string),
+			Action:               raw["action"].(string),
+			IPVersion:            normalizeNetworkACLRuleIPVersion(raw["ip_version"].(int)),
+			Protocol:             rules.Protocol(raw["protocol"].(string)),
+			SourceIPAddress:      raw["source_ip_address"].(string),
+			DestinationIPAddress: raw["destination_ip_address"].(string),
+			SourcePort:           raw["source_port"].(string),
+			DestinationPort:      raw["destination_port"].(string),
+			Enabled:              &enabled,
+		},
+	}
+	if direction == "inbound" {
+		createOpts.SourceAddressGroupID = raw["remote_address_group_id"].(string)
+	} else {
+		createOpts.DestinationAddressGroupID = raw["remote_address_group_id"].(string)
+	}
+
+	log.Printf("[DEBUG] Create Network ACL rule: %#v", createOpts)
+	rule, err := rules.Create(client, createOpts).Extract()
+	if err != nil {
+		return "", fmt.Errorf("Error creating Network ACL rule: %s", err)
+	}
+	return rule.ID, nil
+}
+
+func deleteNetworkACLInlineRules(client *golangsdk.ServiceClient, ids []string) {
+	for _, id := range ids {
+		if err := rules.Delete(client, id).ExtractErr(); err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); !ok {
+				log.Printf("[WARN] Error deleting Network ACL rule %s: %s", id, err)
+			}
+		}
+	}
+}
+
+// syncNetworkACLPolicy replaces the rules of the inbound or outbound firewall
+// policy in a single request. The rules are taken from the list of rule IDs
+// or from the inline rules, of which the unchanged ones are kept and the
+// others are created before and deleted after the policy update. It returns
+// the ID of the policy, which is created if missing.
+func syncNetworkACLPolicy(d *schema.ResourceData, client *golangsdk.ServiceClient, direction string) (string, error) {
+	policyKey := direction + "_policy_id"
+	policyID := d.Get(policyKey).(string)
+	policyName := direction + "_policy_for_" + d.Get("name").(string)
+
+	oldRaw, newRaw := d.GetChange(direction + "_rule")
+	staleIDs := make(map[string][]string)
+	for _, v := range oldRaw.(*schema.Set).List() {
+		raw := v.(map[string]interface{})
+		if id := raw["id"].(string); id != "" {
+			key := networkACLRuleKey(raw)
+			staleIDs[key] = append(staleIDs[key], id)
+		}
+	}
+
+	ruleIDs := make([]string, 0)
+	createdIDs := make([]string, 0)
+	if idsRaw := d.Get(direction + "_rules").([]interface{}); len(idsRaw) > 0 {
+		for _, id := range idsRaw {
+			ruleIDs = append(ruleIDs, id.(string))
+		}
+	} else {
+		desired, err := sortedNetworkACLRules(newRaw.(*schema.Set))
+		if err != nil {
+			return policyID, err
+		}
+		if err := checkNetworkACLInlineRules(direction, desired); err != nil {
+			return policyID, err
+		}
+		for _, raw := range desired {
+			key := networkACLRuleKey(raw)
+			if ids := staleIDs[key]; len(ids) > 0 {
+				ruleIDs = append(ruleIDs, ids[0])
+				staleIDs[key] = ids[1:]
+				continue
+			}
+
+			id, err := createNetworkACLInlineRule(client, direction, raw)
+			if err != nil {
+				deleteNetworkACLInlineRules(client, createdIDs)
+				return policyID, err
+			}
+			ruleIDs = append(ruleIDs, id)
+			createdIDs = append(createdIDs, id)
+		}
+	}
+
+	if policyID != "" {
+		// update the firewall policy, even if the rules are empty
+		policyOpts := policies.UpdateOpts{
+			Name:  policyName,
+			Rules: ruleIDs,
+		}
+
+		log.Printf("[DEBUG] Updating firewall policy with id %s: %#v", policyID, policyOpts)
+		if err := policies.Update(client, policyID, policyOpts).Err; err != nil {
+			deleteNetworkACLInlineRules(client, createdIDs)
+			return policyID, fmt.Errorf("Error updating firewall policy %s: %s", policyID, err)
+		}
+	} else if len(ruleIDs) > 0 {
+		policyOpts := policies.CreateOpts{
+			Name:  policyName,
+			Rules: ruleIDs,
+		}
+
+		log.Printf("[DEBUG] Create firewall policy: %#v", policyOpts)
+		policy, err := policies.Create(client, policyOpts).Extract()
+		if err != nil {
+			deleteNetworkACLInlineRules(client, createdIDs)
+			return policyID, fmt.Errorf("Error creating firewall policy: %s", err)
+		}
+		log.Printf("[DEBUG] Firewall %s policy created: %#v", direction, policy)
+		policyID = policy.ID
+	}
+
+	// the replaced rules are no longer referenced by the policy
+	for _, ids := range staleIDs {
+		deleteNetworkACLInlineRules(client, ids)
+	}
+
+	return policyID, nil
+}
+
+// flattenNetworkACLPolicyRules returns the inline rules in the order of the
+// firewall policy. The priorities in the state are kept as long as they are in
+// the same order as the policy, otherwise, e.g. on import, the rules are
+// numbered from 1.
+func flattenNetworkACLPolicyRules(d *schema.ResourceData, client *golangsdk.ServiceClient,
+	direction, policyID string) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0)
+	if policyID == "" {
+		return result, nil
+	}
+
+	policy, err := policies.Get(client, policyID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving firewall policy %s: %s", policyID, err)
+	}
+
+	for _, id := range policy.Rules {
+		rule, err := getNetworkACLRule(client, id)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving Network ACL rule %s: %s", id, err)
+		}
+		remoteGroupID := rule.DestinationAddressGroupID
+		if direction == "inbound" {
+			remoteGroupID = rule.SourceAddressGroupID
+		}
+
+		protocol := rule.Protocol
+		if protocol == "" {
+			protocol = "any"
+		}
+		result = append(result, map[string]interface{}{
+			"id":                      rule.ID,
+			"action":                  rule.Action,
+			"protocol":                protocol,
+			"name":                    rule.Name,
+			"description":             rule.Description,
+			"ip_version":              rule.IPVersion,
+			"source_ip_address":       rule.SourceIPAddress,
+			"destination_ip_address":  rule.DestinationIPAddress,
+			"remote_address_group_id": remoteGroupID,
+			"source_port":             rule.SourcePort,
+			"destination_port":        rule.DestinationPort,
+			"enabled":                 rule.Enabled,
+		})
+	}
+
+	current, err := sortedNetworkACLRules(d.Get(direction + "_rule").(*schema.Set))
+	inOrder := err == nil && len(current) == len(result)
+	for i := 0; inOrder && i < len(result); i++ {
+		inOrder = networkACLRuleKey(current[i]) == networkACLRuleKey(result[i])
+	}
+	for i, rule := range result {
+		if inOrder {
+			rule["priority"] = current[i]["priority"]
+		} else {
+			rule["priority"] = i + 1
+		}
+	}
+
+	return result, nil
+}
+
+func resourceNetworkACLCreate(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	var portIds []string
+	var inboundPolicyID, outboundPolicyID string
+
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	defer func() {
+		// delete the inline rules and firewall policies when encounter errors
+		if err != nil {
+			for direction, policyID := range map[string]string{
+				"inbound":  inboundPolicyID,
+				"outbound": outboundPolicyID,
+			} {
+				if policyID == "" || len(d.Get(direction+"_rules").([]interface{})) > 0 {
+					continue
+				}
+				if policy, getErr := policies.Get(fwClient, policyID).Extract(); getErr == nil {
+					// the rules must be removed from the policy before they are deleted
+					if updateErr := policies.Update(fwClient, policyID, policies.UpdateOpts{Rules: []string{}}).Err; updateErr == nil {
+						deleteNetworkACLInlineRules(fwClient, policy.Rules)
+					}
+				}
+			}
+		}
+
+		if err != nil && inboundPolicyID != "" {
+			deleteErr := policies.Delete(fwClient, inboundPolicyID).Err
+			if deleteErr != nil {
+				log.Printf("[WARN] Error deleting inbound firewall policy %s: %s", inboundPolicyID, deleteErr)
+			}
+		}
+
+		if err != nil && outboundPolicyID != "" {
+			deleteErr := policies.Delete(fwClient, outboundPolicyID).Err
+			if deleteErr != nil {
+				log.Printf("[WARN] Error deleting outbound firewall policy %s: %s", outboundPolicyID, deleteErr)
+			}
+		}
+	}()
+
+	// get port Ids from subnets
+	subnetsRaw := d.Get("subnets").(*schema.Set).List()
+	if len(subnetsRaw) > 0 {
+		for _, v := range subnetsRaw {
+			port, err := getGWPortFromSubnet(config, v.(string))
+			if err != nil {
+				return err
+			}
+			portIds = append(portIds, port)
+		}
+		log.Printf("[DEBUG] Will attempt to associate Firewall group with subnets: %+v", subnetsRaw)
+	}
+
+	// create inbound and outbound policies
+	inboundPolicyID, err = syncNetworkACLPolicy(d, fwClient, "inbound")
+	if err != nil {
+		return err
+	}
+	outboundPolicyID, err = syncNetworkACLPolicy(d, fwClient, "outbound")
+	if err != nil {
+		return err
+	}
+
+	var createOpts firewall_groups.CreateOptsBuilder
+	createOpts = &firewall_groups.CreateOpts{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		IngressPolicyID: inboundPolicyID,
+		EgressPolicyID:  outboundPolicyID,
+	}
+
+	if len(portIds) > 0 {
+		createOpts = &routerinsertion.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			PortIDs:           portIds,
+		}
+	}
+
+	log.Printf("[DEBUG] Create firewall group: %#v", createOpts)
+	group, err := firewall_groups.Create(fwClient, createOpts).Extract()
+	if err != nil {
+		return err
+	}
+
+	d.SetId(group.ID)
+	log.Printf("[DEBUG] waiting for Firewall group (%s) to become ACTIVE", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		// if none subnets was associated with the firewall group, the state will be "INACTIVE"
+		// so we seems the "INACTIVE" as a target state.
+		Pending:    []string{"PENDING_CREATE"},
+		Target:     []string{"ACTIVE", "INACTIVE"},
+		Refresh:    waitForFirewallGroupActive(fwClient, group.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      2,
+		MinTimeout: 2 * time.Second,
+	}
+	_, stateErr := stateConf.WaitForState()
+	if stateErr != nil {
+		return fmt.Errorf("Error waiting for Firewall group (%s) to become ACTIVE: %s",
+			d.Id(), stateErr)
+	}
+
+	log.Printf("[DEBUG] Firewall group (%s) is active.", group.ID)
+	return resourceNetworkACLRead(d, meta)
+}
+
+func resourceNetworkACLRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	fwClient, err := config.FwV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	var fwGroup huaweicloud.FirewallGroup
+	err = firewall_groups.Get(fwClient, d.Id()).ExtractInto(&fwGroup)
+	if err != nil {
+		return CheckDeleted(d, err, "firewall")
+	}
+
+	log.Printf("[DEBUG] Read SberCloud Firewall group %s: %#v", d.Id(), fwGroup)
+
+	d.Set("region", region)
+	d.Set("name", fwGroup.Name)
+	d.Set("status", fwGroup.Status)
+	d.Set("description", fwGroup.Description)
+	d.Set("inbound_policy_id", fwGroup.IngressPolicyID)
+	d.Set("outbound_policy_id", fwGroup.EgressPolicyID)
+	if err := d.Set("ports", fwGroup.PortIDs); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving ports to state for SberCloud firewall group (%s): %s", d.Id(), err)
+	}
+
+	// the inline rules are left empty when the rules are managed by the lists of IDs
+	for direction, policyID := range map[string]string{
+		"inbound":  fwGroup.IngressPolicyID,
+		"outbound": fwGroup.EgressPolicyID,
+	} {
+		inlineRules := make([]map[string]interface{}, 0)
+		if len(d.Get(direction+"_rules").([]interface{})) == 0 {
+			inlineRules, err = flattenNetworkACLPolicyRules(d, fwClient, direction, policyID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := d.Set(direction+"_rule", inlineRules); err != nil {
+			return fmt.Errorf("Error saving %s_rule to state for SberCloud firewall group (%s): %s", direction, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceNetworkACLUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	// first of all, inbound_policy/rules and outbound_policy/rules should be updated
+	var changed bool
+	opts := firewall_groups.UpdateOpts{}
+	for _, direction := range []string{"inbound", "outbound"} {
+		if !d.HasChanges(direction+"_rules", direction+"_rule") {
+			continue
+		}
+
+		policyKey := direction + "_policy_id"
+		oldPolicyID := d.Get(policyKey).(string)
+		policyID, err := syncNetworkACLPolicy(d, fwClient, direction)
+		if err != nil {
+			return err
+		}
+		if policyID != oldPolicyID {
+			// associate the newly created policy with the firewall group
+			changed = true
+			if direction == "inbound" {
+				opts.IngressPolicyID = policyID
+			} else {
+				opts.EgressPolicyID = policyID
+			}
+			//lintignore:R001
+			d.Set(policyKey, policyID)
+		}
+	}
+
+	// update other parameters
+	if d.HasChanges("name", "description") {
+		changed = true
+		opts.Name = d.Get("name").(string)
+		opts.Description = d.Get("description").(string)
+	}
+
+	var updateOpts firewall_groups.UpdateOptsBuilder
+	var portIds []string
+	if d.HasChange("subnets") {
+		changed = true
+
+		// get port Ids from subnets
+		subnetsRaw := d.Get("subnets").(*schema.Set).List()
+		for _, v := range subnetsRaw {
+			port, err := getGWPortFromSubnet(config, v.(string))
+			if err != nil {
+				return err
+			}
+			portIds = append(portIds, port)
+		}
+		log.Printf("[DEBUG] Will attempt to associate Firewall group with subnets: %+v", subnetsRaw)
+
+		updateOpts = routerinsertion.UpdateOptsExt{
+			UpdateOptsBuilder: opts,
+			PortIDs:           portIds,
+		}
+	} else {
+		updateOpts = opts
+	}
+
+	if changed {
+		log.Printf("[DEBUG] Updating firewall with id %s: %#v", d.Id(), updateOpts)
+		err = firewall_groups.Update(fwClient, d.Id(), updateOpts).Err
+		if err != nil {
+			return err
+		}
+
+		// if none subnets was associated with the firewall group, the state will be "INACTIVE"
+		// so we seems the "INACTIVE" as a target state.
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"PENDING_CREATE", "PENDING_UPDATE"},
+			Target:     []string{"ACTIVE", "INACTIVE"},
+			Refresh:    waitForFirewallGroupActive(fwClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      2,
+			MinTimeout: 2 * time.Second,
+		}
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error updating firewall group (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceNetworkACLRead(d, meta)
+}
+
+func resourceNetworkACLDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy firewall group: %s", d.Id())
+
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	inboundPolicyID := d.Get("inbound_policy_id").(string)
+	outboundPolicyID := d.Get("outbound_policy_id").(string)
+
+	err = firewall_groups.Delete(fwClient, d.Id()).Err
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"DELETING"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForFirewallGroupDeletion(fwClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      2,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting firewall group (%s): %s", d.Id(), err)
+	}
+
+	// delete firewall policies after the firewall group
+	if inboundPolicyID != "" {
+		deleteErr := policies.Delete(fwClient, inboundPolicyID).Err
+		if deleteErr != nil {
+			log.Printf("[WARN] Error deleting inbound firewall policy %s: %s", inboundPolicyID, deleteErr)
+		}
+	}
+
+	if outboundPolicyID != "" {
+		deleteErr := policies.Delete(fwClient, outboundPolicyID).Err
+		if deleteErr != nil {
+			log.Printf("[WARN] Error deleting outbound firewall policy %s: %s", outboundPolicyID, deleteErr)
+		}
+	}
+
+	// delete the inline rules, which are no longer referenced by the policies
+	for _, key := range []string{"inbound_rule", "outbound_rule"} {
+		ruleIDs := make([]string, 0)
+		for _, v := range d.Get(key).(*schema.Set).List() {
+			if id := v.(map[string]interface{})["id"].(string); id != "" {
+				ruleIDs = append(ruleIDs, id)
+			}
+		}
+		deleteNetworkACLInlineRules(fwClient, ruleIDs)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getGWPortFromSubnet(config *config.Config, subnetID string) (string, error) {
+	var gatewayIP string
+	var gatewayPort string
+
+	subnetClient, err := config.NetworkingV1Client(config.Region)
+	if err != nil {
+		return "", fmt.Errorf("Error creating SberCloud vpc client: %s", err)
+	}
+	networkingClient, err := config.NetworkingV2Client(config.Region)
+	if err != nil {
+		return "", fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	// get Gateway IP
+	n, err := subnets.Get(subnetClient, subnetID).Extract()
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving SberCloud subnet %s: %s", subnetID, err)
+	}
+	gatewayIP = n.GatewayIP
+	log.Printf("[DEBUG] the gateway IP address of subnet %s is %s", subnetID, gatewayIP)
+
+	// list all ports in the subnet
+	listOpts := ports.ListOpts{
+		NetworkID: subnetID,
+	}
+	allPages, err := ports.List(networkingClient, listOpts).AllPages()
+	if err != nil {
+		return "", fmt.Errorf("Unable to list SberCloud ports of %s: %s", subnetID, err)
+	}
+
+	var allPorts []ports.Port
+	err = ports.ExtractPortsInto(allPages, &allPorts)
+	if err != nil {
+		return "", fmt.Errorf("Unable to retrieve SberCloud ports of %s: %s", subnetID, err)
+	}
+
+	if len(allPorts) == 0 {
+		return "", fmt.Errorf("No ports was found in %s", subnetID)
+	}
+
+	// Filter IPs by the gatewayIP
+	for _, p := range allPorts {
+		for _, ipObject := range p.FixedIPs {
+			if ipObject.IPAddress == gatewayIP {
+				gatewayPort = p.ID
+				log.Printf("[DEBUG] the gateway port of subnet %s is %s", subnetID, gatewayPort)
+				return gatewayPort, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("No gateway port was found in %s", subnetID)
+}
+
+func normalizeNetworkACLRuleIPVersion(ipv int) golangsdk.IPVersion {
+	// Determine the IP Version
+	var ipVersion golangsdk.IPVersion
+	switch ipv {
+	case 4:
+		ipVersion = golangsdk.IPv4
+	case 6:
+		ipVersion = golangsdk.IPv6
+	}
+
+	return ipVersion
+}
+
+func waitForFirewallGroupActive(fwClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var fw huaweicloud.FirewallGroup
+
+		err := firewall_groups.Get(fwClient, id).ExtractInto(&fw)
+		if err != nil {
+			return nil, "", err
+		}
+		return fw, fw.Status, nil
+	}
+}
+
+func waitForFirewallGroupDeletion(fwClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		fw, err := firewall_groups.Get(fwClient, id).Extract()
+		log.Printf("[DEBUG] Got firewall group %s => %#v", id, fw)
+
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Firewall group %s is actually deleted", id)
+				return "", "DELETED", nil
+			}
+			return nil, "", fmt.Errorf("Unexpected error: %s", err)
+		}
+
+		log.Printf("[DEBUG] Firewall group %s deletion is pending", id)
+		return fw, "DELETING", nil
+	}
+}
@@ -0,0 +1,371 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/policies"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"github.com/huaweicloud/golangsdk/pagination"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// networkACLRule extends the firewall rule of golangsdk with the IP address
+// groups of the source and the destination.
+type networkACLRule struct {
+	rules.Rule
+	SourceAddressGroupID      string `json:"source_address_group_id"`
+	DestinationAddressGroupID string `json:"destination_address_group_id"`
+}
+
+// networkACLRuleCreateOpts extends the rule creation of golangsdk with the IP
+// address groups, which replace the source or destination IP address.
+type networkACLRuleCreateOpts struct {
+	rules.CreateOpts
+	SourceAddressGroupID      string
+	DestinationAddressGroupID string
+}
+
+func (opts networkACLRuleCreateOpts) ToRuleCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToRuleCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	m := b["firewall_rule"].(map[string]interface{})
+	if opts.SourceAddressGroupID != "" {
+		m["source_address_group_id"] = opts.SourceAddressGroupID
+	}
+	if opts.DestinationAddressGroupID != "" {
+		m["destination_address_group_id"] = opts.DestinationAddressGroupID
+	}
+	return b, nil
+}
+
+// networkACLRuleUpdateOpts extends the rule update of golangsdk with the IP
+// address groups. An empty address group is sent as null to remove it.
+type networkACLRuleUpdateOpts struct {
+	rules.UpdateOpts
+	SourceAddressGroupID      *string
+	DestinationAddressGroupID *string
+}
+
+func (opts networkACLRuleUpdateOpts) ToRuleUpdateMap() (map[string]interface{}, error) {
+	b, err := opts.UpdateOpts.ToRuleUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	m := b["firewall_rule"].(map[string]interface{})
+	for key, groupID := range map[string]*string{
+		"source_address_group_id":      opts.SourceAddressGroupID,
+		"destination_address_group_id": opts.DestinationAddressGroupID,
+	} {
+		if groupID == nil {
+			continue
+		}
+		if *groupID == "" {
+			m[key] = nil
+		} else {
+			m[key] = *groupID
+		}
+	}
+	return b, nil
+}
+
+func getNetworkACLRule(client *golangsdk.ServiceClient, id string) (*networkACLRule, error) {
+	var s struct {
+		Rule networkACLRule `json:"firewall_rule"`
+	}
+	if err := rules.Get(client, id).ExtractInto(&s); err != nil {
+		return nil, err
+	}
+	return &s.Rule, nil
+}
+
+func ResourceNetworkACLRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkACLRuleCreate,
+		Read:   resourceNetworkACLRuleRead,
+		Update: resourceNetworkACLRuleUpdate,
+		Delete: resourceNetworkACLRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"tcp", "udp", "icmp", "any",
+				}, true),
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"allow", "deny",
+				}, true),
+			},
+			"ip_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  4,
+			},
+			"source_ip_address": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source_address_group_id"},
+			},
+			"destination_ip_address": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"destination_address_group_id"},
+			},
+			"source_address_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"destination_address_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_port": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"destination_port": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceNetworkACLRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	enabled := d.Get("enabled").(bool)
+	createOpts := networkACLRuleCreateOpts{
+		CreateOpts: rules.CreateOpts{
+			Name:                 d.Get("name").(string),
+			Description:          d.Get("description").(string),
+			Action:               d.Get("action").(string),
+			IPVersion:            normalizeNetworkACLRuleIPVersion(d.Get("ip_version").(int)),
+			Protocol:             normalizeNetworkACLRuleProtocol(d.Get("protocol").(string)),
+			SourceIPAddress:      d.Get("source_ip_address").(string),
+			DestinationIPAddress: d.Get("destination_ip_address").(string),
+			SourcePort:           d.Get("source_port").(string),
+			DestinationPort:      d.Get("destination_port").(string),
+			Enabled:              &enabled,
+		},
+		SourceAddressGroupID:      d.Get("source_address_group_id").(string),
+		DestinationAddressGroupID: d.Get("destination_address_group_id").(string),
+	}
+
+	log.Printf("[DEBUG] Create Network ACL rule: %#v", createOpts)
+	rule, err := rules.Create(fwClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Network ACL rule: %s", err)
+	}
+
+	log.Printf("[DEBUG] Network ACL rule with id %s", rule.ID)
+	d.SetId(rule.ID)
+
+	return resourceNetworkACLRuleRead(d, meta)
+}
+
+func resourceNetworkACLRuleRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	rule, err := getNetworkACLRule(fwClient, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "Network ACL rule")
+	}
+
+	log.Printf("[DEBUG] Retrieve SberCloud Network ACL rule %s: %#v", d.Id(), rule)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("action", rule.Action)
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+	d.Set("ip_version", rule.IPVersion)
+	d.Set("source_ip_address", rule.SourceIPAddress)
+	d.Set("destination_ip_address", rule.DestinationIPAddress)
+	d.Set("source_address_group_id", rule.SourceAddressGroupID)
+	d.Set("destination_address_group_id", rule.DestinationAddressGroupID)
+	d.Set("source_port", rule.SourcePort)
+	d.Set("destination_port", rule.DestinationPort)
+	d.Set("enabled", rule.Enabled)
+
+	if rule.Protocol == "" {
+		d.Set("protocol", "any")
+	} else {
+		d.Set("protocol", rule.Protocol)
+	}
+
+	return nil
+}
+
+func resourceNetworkACLRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	var updateOpts networkACLRuleUpdateOpts
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts.Name = &name
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+	if d.HasChange("protocol") {
+		protocol := d.Get("protocol").(string)
+		updateOpts.Protocol = &protocol
+	}
+	if d.HasChange("action") {
+		action := d.Get("action").(string)
+		updateOpts.Action = &action
+	}
+	if d.HasChange("ip_version") {
+		ipVersion := normalizeNetworkACLRuleIPVersion(d.Get("ip_version").(int))
+		updateOpts.IPVersion = &ipVersion
+	}
+	if d.HasChange("source_ip_address") {
+		sourceIPAddress := d.Get("source_ip_address").(string)
+		updateOpts.SourceIPAddress = &sourceIPAddress
+	}
+	if d.HasChange("source_address_group_id") {
+		sourceGroupID := d.Get("source_address_group_id").(string)
+		updateOpts.SourceAddressGroupID = &sourceGroupID
+	}
+	if d.HasChange("source_port") {
+		sourcePort := d.Get("source_port").(string)
+		updateOpts.SourcePort = &sourcePort
+	}
+	if d.HasChange("destination_ip_address") {
+		destinationIPAddress := d.Get("destination_ip_address").(string)
+		updateOpts.DestinationIPAddress = &destinationIPAddress
+	}
+	if d.HasChange("destination_address_group_id") {
+		destinationGroupID := d.Get("destination_address_group_id").(string)
+		updateOpts.DestinationAddressGroupID = &destinationGroupID
+	}
+	if d.HasChange("destination_port") {
+		destinationPort := d.Get("destination_port").(string)
+		updateOpts.DestinationPort = &destinationPort
+	}
+	if d.HasChange("enabled") {
+		enabled := d.Get("enabled").(bool)
+		updateOpts.Enabled = &enabled
+	}
+
+	log.Printf("[DEBUG] Updating Network ACL rule %s: %#v", d.Id(), updateOpts)
+	err = rules.Update(fwClient, d.Id(), updateOpts).Err
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud Network ACL rule %s: %s", d.Id(), err)
+	}
+
+	return resourceNetworkACLRuleRead(d, meta)
+}
+
+func resourceNetworkACLRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+	}
+
+	rule, err := rules.Get(fwClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Network ACL rule")
+	}
+
+	policyID, err := networkACLRulePolicyID(fwClient, rule.ID)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the policy of Network ACL rule %s: %s", rule.ID, err)
+	}
+	if policyID != "" {
+		_, err := policies.RemoveRule(fwClient, policyID, rule.ID).Extract()
+		if err != nil {
+			return fmt.Errorf("Error removing Network ACL rule %s from policy %s: %s", rule.ID, policyID, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Destroy Network ACL rule: %s", d.Id())
+	return rules.Delete(fwClient, d.Id()).Err
+}
+
+// networkACLRulePolicyID returns the ID of the firewall policy which holds the
+// rule, or "" if the rule is not in any policy.
+func networkACLRulePolicyID(fwClient *golangsdk.ServiceClient, ruleID string) (string, error) {
+	policyID := ""
+	err := policies.List(fwClient, policies.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		policyList, err := policies.ExtractPolicies(page)
+		if err != nil {
+			return false, err
+		}
+		for _, policy := range policyList {
+			for _, rule := range policy.Rules {
+				if rule == ruleID {
+					policyID = policy.ID
+					return false, nil
+				}
+			}
+		}
+		return true, nil
+	})
+	return policyID, err
+}
+
+func normalizeNetworkACLRuleProtocol(p string) rules.Protocol {
+	var protocol rules.Protocol
+	switch p {
+	case "any":
+		protocol = rules.ProtocolAny
+	case "icmp":
+		protocol = rules.ProtocolICMP
+	case "tcp":
+		protocol = rules.ProtocolTCP
+	case "udp":
+		protocol = rules.ProtocolUDP
+	}
+
+	return protocol
+}
@@ -92,6 +92,33 @@ func TestAccNetworkACLRule_anyProtocol(t *testing.T) {
 	})
 }
 
+func TestAccNetworkACLRule_addressGroup(t *testing.T) {
+	resourceKey := "sbercloud_network_acl_rule.rule_1"
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkACLRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkACLRule_addressGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLRuleExists(resourceKey),
+					resource.TestCheckResourceAttrPair(resourceKey, "source_address_group_id",
+						"sbercloud_vpc_address_group.test", "id"),
+					resource.TestCheckResourceAttr(resourceKey, "source_ip_address", ""),
+				),
+			},
+			{
+				ResourceName:      resourceKey,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckNetworkACLRuleDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	fwClient, err := config.FwV2Client(SBC_REGION_NAME)
@@ -198,3 +225,20 @@ resource "sbercloud_network_acl_rule" "rule_any" {
 }
 `, rName)
 }
+
+func testAccNetworkACLRule_addressGroup(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_address_group" "test" {
+  name      = "%[1]s"
+  addresses = ["192.168.10.10", "192.168.1.1-192.168.1.50"]
+}
+
+resource "sbercloud_network_acl_rule" "rule_1" {
+  name                    = "%[1]s"
+  protocol                = "tcp"
+  action                  = "allow"
+  source_address_group_id = sbercloud_vpc_address_group.test.id
+  destination_port        = "443"
+}
+`, rName)
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/firewall_groups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/policies"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/rules"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
@@ -110,6 +112,69 @@ func TestAccNetworkACL_remove(t *testing.T) {
 	})
 }
 
+func TestAccNetworkACL_inlineRules(t *testing.T) {
+	rName := fmt.Sprintf("acc-fw-%s", acctest.RandString(5))
+	resourceKey := "sbercloud_network_acl.fw_1"
+	var fwGroup huaweicloud.FirewallGroup
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkACL_inlineRules(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists(resourceKey, &fwGroup),
+					resource.TestCheckResourceAttr(resourceKey, "inbound_rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceKey, "outbound_rule.#", "1"),
+					testAccCheckNetworkACLPolicyRules(&fwGroup, []string{"ssh", "telnet"}),
+				),
+			},
+			{
+				Config: testAccNetworkACL_inlineRulesUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists(resourceKey, &fwGroup),
+					resource.TestCheckResourceAttr(resourceKey, "inbound_rule.#", "3"),
+					resource.TestCheckResourceAttr(resourceKey, "outbound_rule.#", "0"),
+					testAccCheckNetworkACLPolicyRules(&fwGroup, []string{"office", "ssh", "telnet"}),
+				),
+			},
+			{
+				ResourceName:            resourceKey,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"subnets", "inbound_rule", "outbound_rule"},
+			},
+		},
+	})
+}
+
+func TestAccNetworkACL_remoteAddressGroup(t *testing.T) {
+	rName := fmt.Sprintf("acc-fw-%s", acctest.RandString(5))
+	resourceKey := "sbercloud_network_acl.fw_1"
+	var fwGroup huaweicloud.FirewallGroup
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkACL_remoteAddressGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists(resourceKey, &fwGroup),
+					resource.TestCheckResourceAttrPair(resourceKey, "inbound_rule.0.remote_address_group_id",
+						"sbercloud_vpc_address_group.office", "id"),
+					resource.TestCheckResourceAttrPair(resourceKey, "outbound_rule.0.remote_address_group_id",
+						"sbercloud_vpc_address_group.partner", "id"),
+					testAccCheckNetworkACLPolicyRules(&fwGroup, []string{"office"}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckNetworkACLDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	fwClient, err := config.FwV2Client(SBC_REGION_NAME)
@@ -165,6 +230,35 @@ func testAccCheckNetworkACLExists(n string, fwGroup *huaweicloud.FirewallGroup)
 	}
 }
 
+func testAccCheckNetworkACLPolicyRules(fwGroup *huaweicloud.FirewallGroup, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*config.Config)
+		fwClient, err := config.FwV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud fw client: %s", err)
+		}
+
+		policy, err := policies.Get(fwClient, fwGroup.IngressPolicyID).Extract()
+		if err != nil {
+			return err
+		}
+		if len(policy.Rules) != len(expected) {
+			return fmt.Errorf("Expected %d inbound rules, got %d", len(expected), len(policy.Rules))
+		}
+		for i, id := range policy.Rules {
+			rule, err := rules.Get(fwClient, id).Extract()
+			if err != nil {
+				return err
+			}
+			if rule.Name != expected[i] {
+				return fmt.Errorf("Expected the inbound rule %q at position %d, got %q", expected[i], i+1, rule.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckFWFirewallPortCount(firewall_group *huaweicloud.FirewallGroup, expected int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(firewall_group.PortIDs) != expected {
@@ -254,3 +348,103 @@ resource "sbercloud_network_acl" "fw_1" {
 }
 `, testAccNetworkACLRules(name), name)
 }
+
+func testAccNetworkACL_inlineRules(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_network_acl" "fw_1" {
+  name        = "%s"
+  description = "network acl with inline rules"
+
+  inbound_rule {
+    priority         = 20
+    name             = "telnet"
+    action           = "deny"
+    protocol         = "tcp"
+    destination_port = "23"
+  }
+
+  inbound_rule {
+    priority         = 10
+    name             = "ssh"
+    action           = "allow"
+    protocol         = "tcp"
+    destination_port = "22"
+  }
+
+  outbound_rule {
+    priority = 1
+    name     = "all"
+    action   = "allow"
+    protocol = "any"
+  }
+}
+`, name)
+}
+
+func testAccNetworkACL_inlineRulesUpdate(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_network_acl" "fw_1" {
+  name        = "%s"
+  description = "network acl with inline rules"
+
+  inbound_rule {
+    priority          = 1
+    name              = "office"
+    action            = "allow"
+    protocol          = "any"
+    source_ip_address = "192.168.10.0/24"
+  }
+
+  inbound_rule {
+    priority         = 20
+    name             = "telnet"
+    action           = "deny"
+    protocol         = "tcp"
+    destination_port = "23"
+  }
+
+  inbound_rule {
+    priority         = 10
+    name             = "ssh"
+    action           = "allow"
+    protocol         = "tcp"
+    destination_port = "22"
+  }
+}
+`, name)
+}
+
+func testAccNetworkACL_remoteAddressGroup(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_address_group" "office" {
+  name      = "%[1]s-office"
+  addresses = ["192.168.10.0/24"]
+}
+
+resource "sbercloud_vpc_address_group" "partner" {
+  name      = "%[1]s-partner"
+  addresses = ["172.16.10.0/24"]
+}
+
+resource "sbercloud_network_acl" "fw_1" {
+  name = "%[1]s"
+
+  inbound_rule {
+    priority                = 1
+    name                    = "office"
+    action                  = "allow"
+    protocol                = "any"
+    remote_address_group_id = sbercloud_vpc_address_group.office.id
+  }
+
+  outbound_rule {
+    priority                = 1
+    name                    = "partner"
+    action                  = "allow"
+    protocol                = "tcp"
+    destination_port        = "443"
+    remote_address_group_id = sbercloud_vpc_address_group.partner.id
+  }
+}
+`, name)
+}
@@ -0,0 +1,718 @@
+package sbercloud
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/extradhcpopts"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/portsecurity"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// networkingPort is a port with the extra DHCP options and the port security
+type networkingPort struct {
+	ports.Port
+	extradhcpopts.ExtraDHCPOptsExt
+	portsecurity.PortSecurityExt
+}
+
+func ResourceNetworkingPortV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingPortV2Create,
+		Read:   resourceNetworkingPortV2Read,
+		Update: resourceNetworkingPortV2Update,
+		Delete: resourceNetworkingPortV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"network_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"mac_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"device_owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"device_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"no_security_groups": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"port_security_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"fixed_ip": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"allowed_address_pairs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      networkingPortAllowedAddressPairsHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"extra_dhcp_option": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_version": {
+							Type:     schema.TypeInt,
+							Default:  4,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"value_specs": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"all_fixed_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_security_group_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceNetworkingPortV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	if err := checkNetworkingPortSecurity(d); err != nil {
+		return err
+	}
+
+	securityGroups := expandNetworkingPortSecurityGroups(d.Get("security_group_ids").(*schema.Set))
+	createOpts := huaweicloud.PortCreateOpts{
+		CreateOpts: ports.CreateOpts{
+			Name:         d.Get("name").(string),
+			AdminStateUp: expandNetworkingPortAdminStateUp(d),
+			NetworkID:    d.Get("network_id").(string),
+			MACAddress:   d.Get("mac_address").(string),
+			DeviceOwner:  d.Get("device_owner").(string),
+			DeviceID:     d.Get("device_id").(string),
+			FixedIPs:     expandNetworkingPortFixedIPs(d),
+		},
+		ValueSpecs: huaweicloud.MapValueSpecs(d),
+	}
+
+	// Only set SecurityGroups if one was specified or none is wanted.
+	// Otherwise the default security group is applied.
+	if len(securityGroups) > 0 || d.Get("no_security_groups").(bool) || !expandNetworkingPortSecurityEnabled(d) {
+		createOpts.SecurityGroups = &securityGroups
+	}
+
+	if pairs := expandNetworkingPortAllowedAddressPairs(d); len(pairs) > 0 {
+		createOpts.AllowedAddressPairs = pairs
+	}
+
+	// Declare a finalCreateOpts interface to hold the base create options
+	// extended by the port security and the DHCP options.
+	var finalCreateOpts ports.CreateOptsBuilder
+	finalCreateOpts = createOpts
+
+	if v, ok := d.GetOkExists("port_security_enabled"); ok {
+		enabled := v.(bool)
+		finalCreateOpts = portsecurity.PortCreateOptsExt{
+			CreateOptsBuilder:   finalCreateOpts,
+			PortSecurityEnabled: &enabled,
+		}
+	}
+
+	dhcpOpts := d.Get("extra_dhcp_option").(*schema.Set)
+	if dhcpOpts.Len() > 0 {
+		finalCreateOpts = extradhcpopts.CreateOptsExt{
+			CreateOptsBuilder: finalCreateOpts,
+			ExtraDHCPOpts:     expandNetworkingPortDHCPOptsCreate(dhcpOpts),
+		}
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", finalCreateOpts)
+
+	var p networkingPort
+	err = ports.Create(networkingClient, finalCreateOpts).ExtractInto(&p)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Neutron port: %s", err)
+	}
+	d.SetId(p.ID)
+
+	log.Printf("[DEBUG] Waiting for SberCloud Neutron Port (%s) to become available.", p.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"BUILD"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    waitForNetworkPortActive(networkingClient, p.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for SberCloud Neutron Port (%s) to become available: %s", p.ID, err)
+	}
+
+	return resourceNetworkingPortV2Read(d, meta)
+}
+
+func resourceNetworkingPortV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	var p networkingPort
+	err = ports.Get(networkingClient, d.Id()).ExtractInto(&p)
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Neutron Port")
+	}
+
+	log.Printf("[DEBUG] Retrieved Port %s: %+v", d.Id(), p)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", p.Name)
+	d.Set("admin_state_up", p.AdminStateUp)
+	d.Set("network_id", p.NetworkID)
+	d.Set("mac_address", p.MACAddress)
+	d.Set("device_owner", p.DeviceOwner)
+	d.Set("device_id", p.DeviceID)
+	d.Set("port_security_enabled", p.PortSecurityEnabled)
+	d.Set("status", p.Status)
+
+	// Create a slice of all returned Fixed IPs.
+	// This will be in the order returned by the API,
+	// which is usually alpha-numeric.
+	fixedIPs := make([]map[string]interface{}, len(p.FixedIPs))
+	ips := make([]string, len(p.FixedIPs))
+	for i, ipObject := range p.FixedIPs {
+		fixedIPs[i] = map[string]interface{}{
+			"subnet_id":  ipObject.SubnetID,
+			"ip_address": ipObject.IPAddress,
+		}
+		ips[i] = ipObject.IPAddress
+	}
+	d.Set("fixed_ip", fixedIPs)
+	d.Set("all_fixed_ips", ips)
+
+	// Set all security groups.
+	// This can be different from what the user specified since
+	// the port can have the "default" group automatically applied.
+	d.Set("all_security_group_ids", p.SecurityGroups)
+
+	// the address pairs of the VIPs are owned by sbercloud_networking_vip_associate,
+	// unless they are set in allowed_address_pairs
+	vipAddresses, err := listNetworkingVIPAddresses(networkingClient, p.NetworkID)
+	if err != nil {
+		return err
+	}
+	managed := make(map[string]bool)
+	for _, raw := range d.Get("allowed_address_pairs").(*schema.Set).List() {
+		managed[raw.(map[string]interface{})["ip_address"].(string)] = true
+	}
+	pairs := make([]map[string]interface{}, 0, len(p.AllowedAddressPairs))
+	for _, pairObject := range p.AllowedAddressPairs {
+		if vipAddresses[pairObject.IPAddress] && !managed[pairObject.IPAddress] {
+			continue
+		}
+		pairs = append(pairs, map[string]interface{}{
+			"ip_address":  pairObject.IPAddress,
+			"mac_address": pairObject.MACAddress,
+		})
+	}
+	d.Set("allowed_address_pairs", pairs)
+	d.Set("extra_dhcp_option", flattenNetworkingPortDHCPOpts(p.ExtraDHCPOptsExt))
+
+	return nil
+}
+
+func resourceNetworkingPortV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	if err := checkNetworkingPortSecurity(d); err != nil {
+		return err
+	}
+
+	var hasChange bool
+	var updateOpts ports.UpdateOpts
+
+	if d.HasChange("allowed_address_pairs") {
+		hasChange = true
+		pairs, err := mergeNetworkingPortAllowedAddressPairs(d, networkingClient)
+		if err != nil {
+			return err
+		}
+		updateOpts.AllowedAddressPairs = &pairs
+	}
+
+	if d.HasChange("no_security_groups") && d.Get("no_security_groups").(bool) {
+		hasChange = true
+		securityGroups := []string{}
+		updateOpts.SecurityGroups = &securityGroups
+	}
+
+	if d.HasChange("security_group_ids") {
+		hasChange = true
+		securityGroups := expandNetworkingPortSecurityGroups(d.Get("security_group_ids").(*schema.Set))
+		updateOpts.SecurityGroups = &securityGroups
+	}
+
+	if d.HasChange("name") {
+		hasChange = true
+		updateOpts.Name = d.Get("name").(string)
+	}
+
+	if d.HasChange("admin_state_up") {
+		hasChange = true
+		updateOpts.AdminStateUp = expandNetworkingPortAdminStateUp(d)
+	}
+
+	if d.HasChange("fixed_ip") {
+		hasChange = true
+		updateOpts.FixedIPs = expandNetworkingPortFixedIPs(d)
+	}
+
+	var finalUpdateOpts ports.UpdateOptsBuilder
+	finalUpdateOpts = updateOpts
+
+	if d.HasChange("port_security_enabled") {
+		hasChange = true
+		enabled := expandNetworkingPortSecurityEnabled(d)
+		if !enabled {
+			// the security groups and the address pairs are removed in the
+			// same request, or the port security cannot be disabled
+			securityGroups := []string{}
+			pairs := []ports.AddressPair{}
+			updateOpts.SecurityGroups = &securityGroups
+			updateOpts.AllowedAddressPairs = &pairs
+		}
+		finalUpdateOpts = portsecurity.PortUpdateOptsExt{
+			UpdateOptsBuilder:   updateOpts,
+			PortSecurityEnabled: &enabled,
+		}
+	}
+
+	if hasChange {
+		log.Printf("[DEBUG] Updating Port %s with options: %+v", d.Id(), finalUpdateOpts)
+
+		_, err = ports.Update(networkingClient, d.Id(), finalUpdateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud Neutron Port: %s", err)
+		}
+	}
+
+	// Next, perform any dhcp option changes.
+	if d.HasChange("extra_dhcp_option") {
+		o, n := d.GetChange("extra_dhcp_option")
+		oldDHCPOpts := o.(*schema.Set)
+		newDHCPOpts := n.(*schema.Set)
+
+		// Delete all old DHCP options, regardless of if they still exist.
+		// If they do still exist, they will be re-added below.
+		if oldDHCPOpts.Len() != 0 {
+			dhcpUpdateOpts := extradhcpopts.UpdateOptsExt{
+				UpdateOptsBuilder: &ports.UpdateOpts{},
+				ExtraDHCPOpts:     expandNetworkingPortDHCPOptsDelete(oldDHCPOpts),
+			}
+
+			log.Printf("[DEBUG] Deleting old DHCP opts for port %s", d.Id())
+			_, err = ports.Update(networkingClient, d.Id(), dhcpUpdateOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error updating SberCloud Neutron Port: %s", err)
+			}
+		}
+
+		// Add any new DHCP options and re-add previously set DHCP options.
+		if newDHCPOpts.Len() != 0 {
+			dhcpUpdateOpts := extradhcpopts.UpdateOptsExt{
+				UpdateOptsBuilder: &ports.UpdateOpts{},
+				ExtraDHCPOpts:     expandNetworkingPortDHCPOptsUpdate(newDHCPOpts),
+			}
+
+			log.Printf("[DEBUG] Updating port %s with options: %#v", d.Id(), dhcpUpdateOpts)
+			_, err = ports.Update(networkingClient, d.Id(), dhcpUpdateOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error updating SberCloud Neutron Port: %s", err)
+			}
+		}
+	}
+
+	return resourceNetworkingPortV2Read(d, meta)
+}
+
+func resourceNetworkingPortV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForNetworkPortDelete(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud Neutron Port: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// checkNetworkingPortSecurity checks that a port without the port security has
+// neither security groups nor allowed address pairs, which are rejected by the API.
+func checkNetworkingPortSecurity(d *schema.ResourceData) error {
+	securityGroups := d.Get("security_group_ids").(*schema.Set)
+	if d.Get("no_security_groups").(bool) && securityGroups.Len() > 0 {
+		return fmt.Errorf("Cannot have both no_security_groups and security_group_ids set")
+	}
+
+	if expandNetworkingPortSecurityEnabled(d) {
+		return nil
+	}
+	if securityGroups.Len() > 0 {
+		return fmt.Errorf("Cannot set security_group_ids when port_security_enabled is false")
+	}
+	// the address pairs may be added by other resources, e.g. a VIP association,
+	// so only the new ones are checked
+	pairs := d.Get("allowed_address_pairs").(*schema.Set)
+	if pairs.Len() > 0 && (d.Id() == "" || d.HasChange("allowed_address_pairs")) {
+		return fmt.Errorf("Cannot set allowed_address_pairs when port_security_enabled is false")
+	}
+	return nil
+}
+
+func expandNetworkingPortSecurityEnabled(d *schema.ResourceData) bool {
+	if v, ok := d.GetOkExists("port_security_enabled"); ok {
+		return v.(bool)
+	}
+	return true
+}
+
+func expandNetworkingPortSecurityGroups(v *schema.Set) []string {
+	securityGroups := make([]string, 0, v.Len())
+	for _, v := range v.List() {
+		securityGroups = append(securityGroups, v.(string))
+	}
+	return securityGroups
+}
+
+func expandNetworkingPortFixedIPs(d *schema.ResourceData) interface{} {
+	rawIP := d.Get("fixed_ip").([]interface{})
+
+	if len(rawIP) == 0 {
+		return nil
+	}
+
+	ip := make([]ports.IP, len(rawIP))
+	for i, raw := range rawIP {
+		rawMap := raw.(map[string]interface{})
+		ip[i] = ports.IP{
+			SubnetID:  rawMap["subnet_id"].(string),
+			IPAddress: rawMap["ip_address"].(string),
+		}
+	}
+	return ip
+}
+
+func expandNetworkingPortAllowedAddressPairs(d *schema.ResourceData) []ports.AddressPair {
+	rawPairs := d.Get("allowed_address_pairs").(*schema.Set).List()
+
+	pairs := make([]ports.AddressPair, len(rawPairs))
+	for i, raw := range rawPairs {
+		rawMap := raw.(map[string]interface{})
+		pairs[i] = ports.AddressPair{
+			IPAddress:  rawMap["ip_address"].(string),
+			MACAddress: rawMap["mac_address"].(string),
+		}
+	}
+	return pairs
+}
+
+// mergeNetworkingPortAllowedAddressPairs returns the address pairs of the port
+// with the changes of allowed_address_pairs, and keeps the other address pairs,
+// e.g. added by sbercloud_networking_vip_associate.
+func mergeNetworkingPortAllowedAddressPairs(d *schema.ResourceData, client *golangsdk.ServiceClient) ([]ports.AddressPair, error) {
+	port, err := ports.Get(client, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving SberCloud Neutron Port %s: %s", d.Id(), err)
+	}
+
+	pairs := expandNetworkingPortAllowedAddressPairs(d)
+	managed := make(map[string]bool)
+	o, _ := d.GetChange("allowed_address_pairs")
+	for _, raw := range o.(*schema.Set).List() {
+		managed[raw.(map[string]interface{})["ip_address"].(string)] = true
+	}
+	for _, pair := range pairs {
+		managed[pair.IPAddress] = true
+	}
+	for _, pair := range port.AllowedAddressPairs {
+		if !managed[pair.IPAddress] {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// listNetworkingVIPAddresses returns the IP addresses of the VIPs of the network.
+func listNetworkingVIPAddresses(client *golangsdk.ServiceClient, networkID string) (map[string]bool, error) {
+	listOpts := ports.ListOpts{
+		NetworkID:   networkID,
+		DeviceOwner: "neutron:VIP_PORT",
+	}
+	allPages, err := ports.List(client, listOpts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the VIPs of network %s: %s", networkID, err)
+	}
+	vips, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting the VIPs of network %s: %s", networkID, err)
+	}
+
+	addresses := make(map[string]bool)
+	for _, vip := range vips {
+		for _, ip := range vip.FixedIPs {
+			addresses[ip.IPAddress] = true
+		}
+	}
+	return addresses, nil
+}
+
+func expandNetworkingPortAdminStateUp(d *schema.ResourceData) *bool {
+	value := false
+
+	if raw, ok := d.GetOk("admin_state_up"); ok && raw == true {
+		value = true
+	}
+
+	return &value
+}
+
+func expandNetworkingPortDHCPOptsCreate(dhcpOpts *schema.Set) []extradhcpopts.CreateExtraDHCPOpt {
+	rawDHCPOpts := dhcpOpts.List()
+
+	extraDHCPOpts := make([]extradhcpopts.CreateExtraDHCPOpt, len(rawDHCPOpts))
+	for i, raw := range rawDHCPOpts {
+		rawMap := raw.(map[string]interface{})
+		extraDHCPOpts[i] = extradhcpopts.CreateExtraDHCPOpt{
+			OptName:   rawMap["name"].(string),
+			OptValue:  rawMap["value"].(string),
+			IPVersion: golangsdk.IPVersion(rawMap["ip_version"].(int)),
+		}
+	}
+
+	return extraDHCPOpts
+}
+
+func expandNetworkingPortDHCPOptsUpdate(dhcpOpts *schema.Set) []extradhcpopts.UpdateExtraDHCPOpt {
+	rawDHCPOpts := dhcpOpts.List()
+
+	extraDHCPOpts := make([]extradhcpopts.UpdateExtraDHCPOpt, len(rawDHCPOpts))
+	for i, raw := range rawDHCPOpts {
+		rawMap := raw.(map[string]interface{})
+		optValue := rawMap["value"].(string)
+		extraDHCPOpts[i] = extradhcpopts.UpdateExtraDHCPOpt{
+			OptName:   rawMap["name"].(string),
+			OptValue:  &optValue,
+			IPVersion: golangsdk.IPVersion(rawMap["ip_version"].(int)),
+		}
+	}
+
+	return extraDHCPOpts
+}
+
+func expandNetworkingPortDHCPOptsDelete(dhcpOpts *schema.Set) []extradhcpopts.UpdateExtraDHCPOpt {
+	rawDHCPOpts := dhcpOpts.List()
+
+	extraDHCPOpts := make([]extradhcpopts.UpdateExtraDHCPOpt, len(rawDHCPOpts))
+	for i, raw := range rawDHCPOpts {
+		rawMap := raw.(map[string]interface{})
+		extraDHCPOpts[i] = extradhcpopts.UpdateExtraDHCPOpt{
+			OptName:  rawMap["name"].(string),
+			OptValue: nil,
+		}
+	}
+
+	return extraDHCPOpts
+}
+
+func flattenNetworkingPortDHCPOpts(dhcpOpts extradhcpopts.ExtraDHCPOptsExt) []map[string]interface{} {
+	dhcpOptsSet := make([]map[string]interface{}, len(dhcpOpts.ExtraDHCPOpts))
+
+	for i, dhcpOpt := range dhcpOpts.ExtraDHCPOpts {
+		ipVersion, _ := strconv.Atoi(dhcpOpt.IPVersion)
+		dhcpOptsSet[i] = map[string]interface{}{
+			"ip_version": ipVersion,
+			"name":       dhcpOpt.OptName,
+			"value":      dhcpOpt.OptValue,
+		}
+	}
+
+	return dhcpOptsSet
+}
+
+func networkingPortAllowedAddressPairsHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-%s", m["ip_address"].(string), m["mac_address"].(string)))
+
+	return hashcode.String(buf.String())
+}
+
+func waitForNetworkPortActive(networkingClient *golangsdk.ServiceClient, portID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		p, err := ports.Get(networkingClient, portID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		// a port which is not attached to any device stays DOWN
+		if p.Status == "DOWN" || p.Status == "ACTIVE" {
+			return p, "ACTIVE", nil
+		}
+
+		return p, p.Status, nil
+	}
+}
+
+func waitForNetworkPortDelete(networkingClient *golangsdk.ServiceClient, portID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to delete SberCloud Neutron Port %s", portID)
+
+		p, err := ports.Get(networkingClient, portID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Port %s", portID)
+				return p, "DELETED", nil
+			}
+			return p, "ACTIVE", err
+		}
+
+		err = ports.Delete(networkingClient, portID).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Port %s", portID)
+				return p, "DELETED", nil
+			}
+			return p, "ACTIVE", err
+		}
+
+		log.Printf("[DEBUG] SberCloud Port %s still active.", portID)
+		return p, "ACTIVE", nil
+	}
+}
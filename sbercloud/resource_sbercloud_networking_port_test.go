@@ -0,0 +1,244 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccNetworkingV2Port_basic(t *testing.T) {
+	var port ports.Port
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_networking_port.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2PortDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2Port_basic(rName, "192.168.0.10", "192.168.0.100/32"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2PortExists(resourceName, &port),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "fixed_ip.0.ip_address", "192.168.0.10"),
+					resource.TestCheckResourceAttr(resourceName, "allowed_address_pairs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extra_dhcp_option.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "port_security_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "all_security_group_ids.0",
+						"sbercloud_networking_secgroup.test", "id"),
+				),
+			},
+			{
+				Config: testAccNetworkingV2Port_basic(rName, "192.168.0.11", "10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2PortNotRecreated(resourceName, &port),
+					resource.TestCheckResourceAttr(resourceName, "fixed_ip.0.ip_address", "192.168.0.11"),
+					resource.TestCheckResourceAttr(resourceName, "all_fixed_ips.0", "192.168.0.11"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"security_group_ids"},
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Port_noPortSecurity(t *testing.T) {
+	var port ports.Port
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_networking_port.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2PortDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2Port_noPortSecurity(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2PortExists(resourceName, &port),
+					resource.TestCheckResourceAttr(resourceName, "port_security_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "all_security_group_ids.#", "0"),
+					resource.TestCheckResourceAttrPair("sbercloud_compute_interface_attach.test", "port_id",
+						resourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2PortDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_networking_port" {
+			continue
+		}
+
+		_, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Port still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingV2PortExists(n string, port *ports.Port) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Port not found")
+		}
+
+		*port = *found
+
+		return nil
+	}
+}
+
+func testAccCheckNetworkingV2PortNotRecreated(n string, port *ports.Port) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != port.ID {
+			return fmt.Errorf("Port is recreated, the ID changed from %s to %s", port.ID, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccNetworkingV2Port_base = `
+resource "sbercloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_subnet" "test" {
+  vpc_id     = sbercloud_vpc.test.id
+  name       = "%[1]s"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+}
+`
+
+func testAccNetworkingV2Port_basic(rName, ipAddress, pairAddress string) string {
+	return fmt.Sprintf(testAccNetworkingV2Port_base+`
+resource "sbercloud_networking_secgroup" "test" {
+  name = "%[1]s"
+}
+
+resource "sbercloud_networking_port" "test" {
+  name               = "%[1]s"
+  network_id         = sbercloud_vpc_subnet.test.id
+  security_group_ids = [sbercloud_networking_secgroup.test.id]
+
+  fixed_ip {
+    subnet_id  = sbercloud_vpc_subnet.test.subnet_id
+    ip_address = "%[2]s"
+  }
+
+  allowed_address_pairs {
+    ip_address = "%[3]s"
+  }
+
+  extra_dhcp_option {
+    name  = "domain-name"
+    value = "example.com"
+  }
+}
+`, rName, ipAddress, pairAddress)
+}
+
+func testAccNetworkingV2Port_noPortSecurity(rName string) string {
+	return fmt.Sprintf(testAccNetworkingV2Port_base+`
+data "sbercloud_availability_zones" "test" {}
+
+data "sbercloud_compute_flavors" "test" {
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "sbercloud_images_image" "test" {
+  name        = "Ubuntu 18.04 server 64bit"
+  most_recent = true
+}
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%[1]s"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = sbercloud_vpc_subnet.test.id
+  }
+}
+
+resource "sbercloud_vpc_subnet" "appliance" {
+  vpc_id     = sbercloud_vpc.test.id
+  name       = "%[1]s-appliance"
+  cidr       = "192.168.1.0/24"
+  gateway_ip = "192.168.1.1"
+}
+
+resource "sbercloud_networking_port" "test" {
+  name                  = "%[1]s"
+  network_id            = sbercloud_vpc_subnet.appliance.id
+  port_security_enabled = false
+
+  fixed_ip {
+    subnet_id  = sbercloud_vpc_subnet.appliance.subnet_id
+    ip_address = "192.168.1.10"
+  }
+}
+
+resource "sbercloud_compute_interface_attach" "test" {
+  instance_id = sbercloud_compute_instance.test.id
+  port_id     = sbercloud_networking_port.test.id
+}
+`, rName)
+}
@@ -0,0 +1,528 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/security/securitygroups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/security/groups"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// secGroupRule is a security group rule of the VPC v3 API, which supports
+// multiple port ranges and remote address groups.
+type secGroupRule struct {
+	ID                   string `json:"id,omitempty"`
+	SecurityGroupID      string `json:"security_group_id,omitempty"`
+	Direction            string `json:"direction,omitempty"`
+	Ethertype            string `json:"ethertype,omitempty"`
+	Protocol             string `json:"protocol,omitempty"`
+	Multiport            string `json:"multiport,omitempty"`
+	RemoteIPPrefix       string `json:"remote_ip_prefix,omitempty"`
+	RemoteGroupID        string `json:"remote_group_id,omitempty"`
+	RemoteAddressGroupID string `json:"remote_address_group_id,omitempty"`
+	Description          string `json:"description,omitempty"`
+}
+
+var secGroupRulePortsRegexp = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+// secGroupRuleSchema returns the schema of the ingress and egress rules of the
+// security group, which are managed as an authoritative set once configured.
+// It is not computed, so that the rules of a direction can be removed by an
+// empty set. Whether a direction is managed is tracked by <direction>_managed,
+// as an empty set can not be told apart from a direction left out.
+func secGroupRuleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:       schema.TypeSet,
+		Optional:   true,
+		ConfigMode: schema.SchemaConfigModeAttr,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ethertype": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "IPv4",
+					ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+				},
+				"protocol": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"ports": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringMatch(secGroupRulePortsRegexp,
+						"ports must be a comma-separated list of ports and port ranges, e.g. 22,80,8000-9000"),
+				},
+				"remote_ip_prefix": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: utils.ValidateCIDR,
+				},
+				"remote_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"remote_address_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringLenBetween(0, 255),
+				},
+			},
+		},
+	}
+}
+
+func ResourceNetworkingSecGroupV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingSecGroupV2Create,
+		Read:   resourceNetworkingSecGroupV2Read,
+		Update: resourceNetworkingSecGroupV2Update,
+		Delete: resourceNetworkingSecGroupV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkingSecGroupV2Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"delete_default_rules": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ingress": secGroupRuleSchema(),
+			"egress":  secGroupRuleSchema(),
+			"ingress_managed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"egress_managed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:       schema.TypeString,
+				Optional:   true,
+				ForceNew:   true,
+				Computed:   true,
+				Deprecated: "tenant_id is deprecated",
+			},
+		},
+	}
+}
+
+func secGroupRuleURL(client *golangsdk.ServiceClient, id ...string) string {
+	return client.ServiceURL(append([]string{client.ProjectID, "vpc", "security-group-rules"}, id...)...)
+}
+
+func listSecGroupRules(vpcClient *golangsdk.ServiceClient, securityGroupID string) ([]secGroupRule, error) {
+	client := vpcV3Client(vpcClient)
+
+	allRules := make([]secGroupRule, 0)
+	marker := ""
+	for {
+		url := fmt.Sprintf("%s?security_group_id=%s&limit=2000", secGroupRuleURL(client), securityGroupID)
+		if marker != "" {
+			url += "&marker=" + marker
+		}
+
+		var rst golangsdk.Result
+		_, rst.Err = client.Get(url, &rst.Body, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if rst.Err != nil {
+			return nil, rst.Err
+		}
+
+		var page struct {
+			SecurityGroupRules []secGroupRule `json:"security_group_rules"`
+		}
+		if err := rst.ExtractInto(&page); err != nil {
+			return nil, err
+		}
+
+		allRules = append(allRules, page.SecurityGroupRules...)
+		if len(page.SecurityGroupRules) < 2000 {
+			return allRules, nil
+		}
+		marker = page.SecurityGroupRules[len(page.SecurityGroupRules)-1].ID
+	}
+}
+
+func createSecGroupRule(vpcClient *golangsdk.ServiceClient, rule secGroupRule) (*secGroupRule, error) {
+	client := vpcV3Client(vpcClient)
+	body := map[string]interface{}{"security_group_rule": rule}
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(secGroupRuleURL(client), body, &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	return extractSecGroupRule(rst)
+}
+
+func getSecGroupRule(vpcClient *golangsdk.ServiceClient, id string) (*secGroupRule, error) {
+	client := vpcV3Client(vpcClient)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(secGroupRuleURL(client, id), &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return extractSecGroupRule(rst)
+}
+
+func extractSecGroupRule(rst golangsdk.Result) (*secGroupRule, error) {
+	if rst.Err != nil {
+		return nil, rst.Err
+	}
+
+	var response struct {
+		SecurityGroupRule secGroupRule `json:"security_group_rule"`
+	}
+	err := rst.ExtractInto(&response)
+	return &response.SecurityGroupRule, err
+}
+
+func deleteSecGroupRule(vpcClient *golangsdk.ServiceClient, id string) error {
+	client := vpcV3Client(vpcClient)
+	_, err := client.Delete(secGroupRuleURL(client, id), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	if _, ok := err.(golangsdk.ErrDefault404); ok {
+		return nil
+	}
+	return err
+}
+
+func expandSecGroupRule(securityGroupID, direction string, raw map[string]interface{}) secGroupRule {
+	return secGroupRule{
+		SecurityGroupID:      securityGroupID,
+		Direction:            direction,
+		Ethertype:            raw["ethertype"].(string),
+		Protocol:             raw["protocol"].(string),
+		Multiport:            raw["ports"].(string),
+		RemoteIPPrefix:       raw["remote_ip_prefix"].(string),
+		RemoteGroupID:        raw["remote_group_id"].(string),
+		RemoteAddressGroupID: raw["remote_address_group_id"].(string),
+		Description:          raw["description"].(string),
+	}
+}
+
+func flattenSecGroupRules(rules []secGroupRule, direction string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Direction != direction {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"ethertype":               rule.Ethertype,
+			"protocol":                rule.Protocol,
+			"ports":                   rule.Multiport,
+			"remote_ip_prefix":        rule.RemoteIPPrefix,
+			"remote_group_id":         rule.RemoteGroupID,
+			"remote_address_group_id": rule.RemoteAddressGroupID,
+			"description":             rule.Description,
+		})
+	}
+	return result
+}
+
+// secGroupRuleKey identifies a rule by its configurable fields, as the rules
+// in the set have no IDs.
+func secGroupRuleKey(rule secGroupRule) string {
+	return strings.Join([]string{
+		rule.Direction, rule.Ethertype, rule.Protocol, rule.Multiport, rule.RemoteIPPrefix,
+		rule.RemoteGroupID, rule.RemoteAddressGroupID, rule.Description,
+	}, "|")
+}
+
+// syncSecGroupRules makes the rules of a direction match the desired set: the
+// rules not in the set are deleted and the missing rules are created, while
+// the unchanged rules are kept.
+func syncSecGroupRules(vpcClient *golangsdk.ServiceClient, securityGroupID, direction string,
+	existing []secGroupRule, desired *schema.Set) error {
+	wanted := make(map[string]secGroupRule)
+	for _, raw := range desired.List() {
+		rule := expandSecGroupRule(securityGroupID, direction, raw.(map[string]interface{}))
+		wanted[secGroupRuleKey(rule)] = rule
+	}
+
+	for _, rule := range existing {
+		if rule.Direction != direction {
+			continue
+		}
+		key := secGroupRuleKey(rule)
+		if _, ok := wanted[key]; ok {
+			delete(wanted, key)
+			continue
+		}
+		log.Printf("[DEBUG] Deleting %s rule %s of security group %s", direction, rule.ID, securityGroupID)
+		if err := deleteSecGroupRule(vpcClient, rule.ID); err != nil {
+			return fmt.Errorf("Error deleting %s rule %s: %s", direction, rule.ID, err)
+		}
+	}
+
+	for _, rule := range wanted {
+		log.Printf("[DEBUG] Creating %s rule of security group %s: %#v", direction, securityGroupID, rule)
+		if _, err := createSecGroupRule(vpcClient, rule); err != nil {
+			return fmt.Errorf("Error creating %s rule: %s", direction, err)
+		}
+	}
+	return nil
+}
+
+func resourceNetworkingSecGroupV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	segClient, err := config.SecurityGroupV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud security group client: %s", err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	// only name and enterprise_project_id are supported
+	opts := securitygroups.CreateOpts{
+		Name:                d.Get("name").(string),
+		EnterpriseProjectId: GetEnterpriseProjectID(d, config),
+	}
+
+	log.Printf("[DEBUG] Create SberCloud Security Group: %#v", opts)
+	securityGroup, err := securitygroups.Create(segClient, opts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating Security Group: %s", err)
+	}
+
+	d.SetId(securityGroup.ID)
+
+	description := d.Get("description").(string)
+	if description != "" {
+		updateOpts := groups.UpdateOpts{
+			Description: &description,
+		}
+		_, err = groups.Update(networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating description of security group %s: %s", d.Id(), err)
+		}
+	}
+
+	// The default rules are deleted if it has been requested, and replaced by
+	// the configured rules of a direction.
+	deleteDefaultRules := d.Get("delete_default_rules").(bool)
+	ingress, egress := d.Get("ingress").(*schema.Set), d.Get("egress").(*schema.Set)
+	if deleteDefaultRules || ingress.Len() > 0 || egress.Len() > 0 {
+		existing, err := listSecGroupRules(vpcClient, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving rules of security group %s: %s", d.Id(), err)
+		}
+		for direction, rules := range map[string]*schema.Set{"ingress": ingress, "egress": egress} {
+			if !deleteDefaultRules && rules.Len() == 0 {
+				continue
+			}
+			if err := syncSecGroupRules(vpcClient, d.Id(), direction, existing, rules); err != nil {
+				return fmt.Errorf("Error setting rules of security group %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceNetworkingSecGroupV2Read(d, meta)
+}
+
+func resourceNetworkingSecGroupV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	segClient, err := config.SecurityGroupV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	log.Printf("[DEBUG] Retrieve information about security group: %s", d.Id())
+	securityGroup, err := securitygroups.Get(segClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "SberCloud Security group")
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", securityGroup.Name)
+	d.Set("description", securityGroup.Description)
+	d.Set("enterprise_project_id", securityGroup.EnterpriseProjectId)
+
+	// all the rules of a managed direction are saved, so that the rules added
+	// outside of terraform are detected as drift of the configured rules, also
+	// after the rules were removed by an empty set. The rules of a direction
+	// which was never configured, e.g. managed by the
+	// sbercloud_networking_secgroup_rule resources, are left out of the state.
+	var managed []string
+	for _, direction := range []string{"ingress", "egress"} {
+		if d.Get(direction+"_managed").(bool) || d.Get(direction).(*schema.Set).Len() > 0 {
+			managed = append(managed, direction)
+			d.Set(direction+"_managed", true)
+		}
+	}
+	if len(managed) == 0 {
+		return nil
+	}
+	rules, err := listSecGroupRules(vpcClient, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving rules of security group %s: %s", d.Id(), err)
+	}
+	for _, direction := range managed {
+		if err := d.Set(direction, flattenSecGroupRules(rules, direction)); err != nil {
+			return fmt.Errorf("Error setting %s rules of security group %s: %s", direction, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceNetworkingSecGroupV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	if d.HasChanges("name", "description") {
+		description := d.Get("description").(string)
+		updateOpts := groups.UpdateOpts{
+			Name:        d.Get("name").(string),
+			Description: &description,
+		}
+
+		log.Printf("[DEBUG] Updating SecGroup %s with options: %#v", d.Id(), updateOpts)
+		_, err = groups.Update(networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud SecGroup: %s", err)
+		}
+	}
+
+	if d.HasChanges("ingress", "egress") {
+		vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+		}
+		existing, err := listSecGroupRules(vpcClient, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving rules of security group %s: %s", d.Id(), err)
+		}
+		for _, direction := range []string{"ingress", "egress"} {
+			if !d.HasChange(direction) {
+				continue
+			}
+			if err := syncSecGroupRules(vpcClient, d.Id(), direction, existing, d.Get(direction).(*schema.Set)); err != nil {
+				return fmt.Errorf("Error updating rules of security group %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceNetworkingSecGroupV2Read(d, meta)
+}
+
+func resourceNetworkingSecGroupV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	segClient, err := config.SecurityGroupV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForSecGroupDelete(segClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud Security Group: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceNetworkingSecGroupV2Import imports the rules of both directions as
+// managed rules.
+func resourceNetworkingSecGroupV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("ingress_managed", true)
+	d.Set("egress_managed", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+func waitForSecGroupDelete(segClient *golangsdk.ServiceClient, secGroupId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to delete SberCloud Security Group %s.\n", secGroupId)
+
+		r, err := securitygroups.Get(segClient, secGroupId).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Security Group %s", secGroupId)
+				return r, "DELETED", nil
+			}
+			return r, "ACTIVE", err
+		}
+
+		err = securitygroups.Delete(segClient, secGroupId).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Security Group %s", secGroupId)
+				return r, "DELETED", nil
+			}
+			if errCode, ok := err.(golangsdk.ErrUnexpectedResponseCode); ok {
+				if errCode.Actual == 409 {
+					return r, "ACTIVE", nil
+				}
+			}
+			return r, "ACTIVE", err
+		}
+
+		log.Printf("[DEBUG] SberCloud Security Group %s still active.\n", secGroupId)
+		return r, "ACTIVE", nil
+	}
+}
@@ -0,0 +1,396 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/security/rules"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func ResourceNetworkingSecGroupRuleV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingSecGroupRuleV2Create,
+		Read:   resourceNetworkingSecGroupRuleV2Read,
+		Delete: resourceNetworkingSecGroupRuleV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"direction": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ingress", "egress",
+				}, true),
+			},
+			"ethertype": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"IPv4", "IPv6",
+				}, true),
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"port_range_min": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"port_range_max": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"remote_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ConflictsWith: []string{"remote_address_group_id"},
+			},
+			"remote_ip_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ConflictsWith: []string{"remote_address_group_id"},
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"remote_address_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:       schema.TypeString,
+				Optional:   true,
+				ForceNew:   true,
+				Computed:   true,
+				Deprecated: "tenant_id is deprecated",
+			},
+		},
+	}
+}
+
+func resourceNetworkingSecGroupRuleV2Create(d *schema.ResourceData, meta interface{}) error {
+
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	portRangeMin := d.Get("port_range_min").(int)
+	portRangeMax := d.Get("port_range_max").(int)
+	protocol := d.Get("protocol").(string)
+
+	if protocol == "" {
+		if portRangeMin != 0 || portRangeMax != 0 {
+			return fmt.Errorf("A protocol must be specified when using port_range_min and port_range_max")
+		}
+	}
+
+	// Address groups are only supported by the VPC v3 API
+	if v, ok := d.GetOk("remote_address_group_id"); ok {
+		return resourceNetworkingSecGroupRuleV3Create(d, meta, v.(string))
+	}
+
+	opts := rules.CreateOpts{
+		Description:    d.Get("description").(string),
+		SecGroupID:     d.Get("security_group_id").(string),
+		PortRangeMin:   d.Get("port_range_min").(int),
+		PortRangeMax:   d.Get("port_range_max").(int),
+		RemoteGroupID:  d.Get("remote_group_id").(string),
+		RemoteIPPrefix: d.Get("remote_ip_prefix").(string),
+		TenantID:       d.Get("tenant_id").(string),
+	}
+
+	if v, ok := d.GetOk("direction"); ok {
+		direction := resourceNetworkingSecGroupRuleV2DetermineDirection(v.(string))
+		opts.Direction = direction
+	}
+
+	if v, ok := d.GetOk("ethertype"); ok {
+		ethertype := resourceNetworkingSecGroupRuleV2DetermineEtherType(v.(string))
+		opts.EtherType = ethertype
+	}
+
+	if v, ok := d.GetOk("protocol"); ok {
+		protocol := resourceNetworkingSecGroupRuleV2DetermineProtocol(v.(string))
+		opts.Protocol = protocol
+	}
+
+	log.Printf("[DEBUG] Create SberCloud Neutron security group: %#v", opts)
+
+	security_group_rule, err := rules.Create(networkingClient, opts).Extract()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] SberCloud Neutron Security Group Rule created: %#v", security_group_rule)
+
+	d.SetId(security_group_rule.ID)
+
+	return resourceNetworkingSecGroupRuleV2Read(d, meta)
+}
+
+func resourceNetworkingSecGroupRuleV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about security group rule: %s", d.Id())
+
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	security_group_rule, err := rules.Get(networkingClient, d.Id()).Extract()
+
+	if err != nil {
+		return CheckDeleted(d, err, "SberCloud Security Group Rule")
+	}
+
+	d.Set("direction", security_group_rule.Direction)
+	d.Set("description", security_group_rule.Description)
+	d.Set("ethertype", security_group_rule.EtherType)
+	d.Set("protocol", security_group_rule.Protocol)
+	d.Set("port_range_min", security_group_rule.PortRangeMin)
+	d.Set("port_range_max", security_group_rule.PortRangeMax)
+	d.Set("remote_group_id", security_group_rule.RemoteGroupID)
+	d.Set("remote_ip_prefix", security_group_rule.RemoteIPPrefix)
+	d.Set("security_group_id", security_group_rule.SecGroupID)
+	d.Set("tenant_id", security_group_rule.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	// The neutron API leaves out the address group, the rule refers to one
+	// only when there is neither a remote CIDR nor a remote security group
+	remoteAddressGroupID := ""
+	if security_group_rule.RemoteIPPrefix == "" && security_group_rule.RemoteGroupID == "" {
+		vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+		}
+		rule, err := getSecGroupRule(vpcClient, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving SberCloud Security Group Rule %s: %s", d.Id(), err)
+		}
+		remoteAddressGroupID = rule.RemoteAddressGroupID
+	}
+	d.Set("remote_address_group_id", remoteAddressGroupID)
+
+	return nil
+}
+
+func resourceNetworkingSecGroupRuleV3Create(d *schema.ResourceData, meta interface{}, remoteAddressGroupID string) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	opts := secGroupRule{
+		SecurityGroupID:      d.Get("security_group_id").(string),
+		Direction:            d.Get("direction").(string),
+		Ethertype:            d.Get("ethertype").(string),
+		Protocol:             d.Get("protocol").(string),
+		RemoteAddressGroupID: remoteAddressGroupID,
+		Description:          d.Get("description").(string),
+	}
+	portRangeMin, portRangeMax := d.Get("port_range_min").(int), d.Get("port_range_max").(int)
+	if portRangeMin != 0 {
+		opts.Multiport = strconv.Itoa(portRangeMin)
+		if portRangeMax != 0 && portRangeMax != portRangeMin {
+			opts.Multiport += "-" + strconv.Itoa(portRangeMax)
+		}
+	}
+	log.Printf("[DEBUG] Create SberCloud Security Group Rule: %#v", opts)
+
+	rule, err := createSecGroupRule(vpcClient, opts)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Security Group Rule: %s", err)
+	}
+
+	log.Printf("[DEBUG] SberCloud Security Group Rule created: %#v", rule)
+	d.SetId(rule.ID)
+
+	return resourceNetworkingSecGroupRuleV2Read(d, meta)
+}
+
+func resourceNetworkingSecGroupRuleV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy security group rule: %s", d.Id())
+
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForSecGroupRuleDelete(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      8 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud Neutron Security Group Rule: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceNetworkingSecGroupRuleV2DetermineDirection(v string) rules.RuleDirection {
+	var direction rules.RuleDirection
+	switch v {
+	case "ingress":
+		direction = rules.DirIngress
+	case "egress":
+		direction = rules.DirEgress
+	}
+
+	return direction
+}
+
+func resourceNetworkingSecGroupRuleV2DetermineEtherType(v string) rules.RuleEtherType {
+	var etherType rules.RuleEtherType
+	switch v {
+	case "IPv4":
+		etherType = rules.EtherType4
+	case "IPv6":
+		etherType = rules.EtherType6
+	}
+
+	return etherType
+}
+
+func resourceNetworkingSecGroupRuleV2DetermineProtocol(v string) rules.RuleProtocol {
+	var protocol rules.RuleProtocol
+
+	// Check and see if the requested protocol matched a list of known protocol names.
+	switch v {
+	case "tcp":
+		protocol = rules.ProtocolTCP
+	case "udp":
+		protocol = rules.ProtocolUDP
+	case "icmp":
+		protocol = rules.ProtocolICMP
+	case "ah":
+		protocol = rules.ProtocolAH
+	case "dccp":
+		protocol = rules.ProtocolDCCP
+	case "egp":
+		protocol = rules.ProtocolEGP
+	case "esp":
+		protocol = rules.ProtocolESP
+	case "gre":
+		protocol = rules.ProtocolGRE
+	case "igmp":
+		protocol = rules.ProtocolIGMP
+	case "ipv6-encap":
+		protocol = rules.ProtocolIPv6Encap
+	case "ipv6-frag":
+		protocol = rules.ProtocolIPv6Frag
+	case "ipv6-icmp":
+		protocol = rules.ProtocolIPv6ICMP
+	case "ipv6-nonxt":
+		protocol = rules.ProtocolIPv6NoNxt
+	case "ipv6-opts":
+		protocol = rules.ProtocolIPv6Opts
+	case "ipv6-route":
+		protocol = rules.ProtocolIPv6Route
+	case "ospf":
+		protocol = rules.ProtocolOSPF
+	case "pgm":
+		protocol = rules.ProtocolPGM
+	case "rsvp":
+		protocol = rules.ProtocolRSVP
+	case "sctp":
+		protocol = rules.ProtocolSCTP
+	case "udplite":
+		protocol = rules.ProtocolUDPLite
+	case "vrrp":
+		protocol = rules.ProtocolVRRP
+	}
+
+	// If the protocol wasn't matched above, see if it's an integer.
+	if protocol == "" {
+		_, err := strconv.Atoi(v)
+		if err == nil {
+			protocol = rules.RuleProtocol(v)
+		}
+	}
+
+	return protocol
+}
+
+func waitForSecGroupRuleDelete(networkingClient *golangsdk.ServiceClient, secGroupRuleId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to delete SberCloud Security Group Rule %s.\n", secGroupRuleId)
+
+		r, err := rules.Get(networkingClient, secGroupRuleId).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Neutron Security Group Rule %s", secGroupRuleId)
+				return r, "DELETED", nil
+			}
+			return r, "ACTIVE", err
+		}
+
+		err = rules.Delete(networkingClient, secGroupRuleId).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud Neutron Security Group Rule %s", secGroupRuleId)
+				return r, "DELETED", nil
+			}
+			return r, "ACTIVE", err
+		}
+
+		log.Printf("[DEBUG] SberCloud Neutron Security Group Rule %s still active.\n", secGroupRuleId)
+		return r, "ACTIVE", nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
@@ -115,6 +116,34 @@ func TestAccNetworkingV2SecGroupRule_numericProtocol(t *testing.T) {
 	})
 }
 
+func TestAccNetworkingV2SecGroupRule_addressGroup(t *testing.T) {
+	var secgroup_rule_1 rules.SecGroupRule
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_networking_secgroup_rule.secgroup_rule_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2SecGroupRule_addressGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupRuleExists(resourceName, &secgroup_rule_1),
+					resource.TestCheckResourceAttrPair(resourceName, "remote_address_group_id",
+						"sbercloud_vpc_address_group.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "remote_ip_prefix", ""),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckNetworkingV2SecGroupRuleDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
@@ -273,3 +302,27 @@ resource "sbercloud_networking_secgroup_rule" "secgroup_rule_1" {
   security_group_id = "${sbercloud_networking_secgroup.secgroup_1.id}"
 }
 `
+
+func testAccNetworkingV2SecGroupRule_addressGroup(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_networking_secgroup" "secgroup_1" {
+  name        = "%[1]s"
+  description = "terraform security group rule acceptance test"
+}
+
+resource "sbercloud_vpc_address_group" "test" {
+  name      = "%[1]s"
+  addresses = ["192.168.10.10", "192.168.1.1-192.168.1.50"]
+}
+
+resource "sbercloud_networking_secgroup_rule" "secgroup_rule_1" {
+  direction               = "ingress"
+  ethertype               = "IPv4"
+  port_range_max          = 443
+  port_range_min          = 443
+  protocol                = "tcp"
+  remote_address_group_id = sbercloud_vpc_address_group.test.id
+  security_group_id       = sbercloud_networking_secgroup.secgroup_1.id
+}
+`, rName)
+}
@@ -84,6 +84,55 @@ func TestAccNetworkingV2SecGroup_timeout(t *testing.T) {
 	})
 }
 
+func TestAccNetworkingV2SecGroup_rules(t *testing.T) {
+	var security_group groups.SecGroup
+	resourceName := "sbercloud_networking_secgroup.secgroup_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2SecGroup_rules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(resourceName, &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 3),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkingV2SecGroup_rulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(resourceName, &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 3),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"delete_default_rules",
+				},
+			},
+			{
+				Config: testAccNetworkingV2SecGroup_rulesNoIngress,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(resourceName, &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 1),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "ingress_managed", "true"),
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckNetworkingV2SecGroupDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
@@ -181,3 +230,63 @@ resource "sbercloud_networking_secgroup" "secgroup_1" {
   }
 }
 `
+
+const testAccNetworkingV2SecGroup_rules = `
+resource "sbercloud_networking_secgroup" "secgroup_1" {
+  name                 = "security_group_rules"
+  description          = "terraform security group acceptance test"
+  delete_default_rules = true
+
+  ingress {
+    protocol         = "tcp"
+    ports            = "22,443,8000-9000"
+    remote_ip_prefix = "10.0.0.0/8"
+    description      = "ssh and web"
+  }
+
+  egress {
+    ethertype = "IPv4"
+  }
+
+  egress {
+    ethertype = "IPv6"
+  }
+}
+`
+
+const testAccNetworkingV2SecGroup_rulesUpdate = `
+resource "sbercloud_networking_secgroup" "secgroup_1" {
+  name                 = "security_group_rules"
+  description          = "terraform security group acceptance test"
+  delete_default_rules = true
+
+  ingress {
+    protocol         = "tcp"
+    ports            = "22,443,8000-9000"
+    remote_ip_prefix = "10.0.0.0/8"
+    description      = "ssh and web"
+  }
+
+  ingress {
+    protocol         = "icmp"
+    remote_ip_prefix = "10.0.0.0/8"
+  }
+
+  egress {
+    ethertype = "IPv4"
+  }
+}
+`
+
+const testAccNetworkingV2SecGroup_rulesNoIngress = `
+resource "sbercloud_networking_secgroup" "secgroup_1" {
+  name                 = "security_group_rules"
+  description          = "terraform security group acceptance test"
+  delete_default_rules = true
+  ingress              = []
+
+  egress {
+    ethertype = "IPv4"
+  }
+}
+`
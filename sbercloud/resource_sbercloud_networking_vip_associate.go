@@ -0,0 +1,274 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// ResourceNetworkingVIPAssociateV2 associates a VIP with the member ports, e.g.
+// of the ECS running keepalived. The fixed IPs of the ports are listed in the
+// allowed address pairs of the VIP, and the VIP address is added to the allowed
+// address pairs of every port, so that the port may send and receive traffic of
+// the VIP. The other address pairs of the ports are kept.
+func ResourceNetworkingVIPAssociateV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingVIPAssociateV2Create,
+		Update: resourceNetworkingVIPAssociateV2Update,
+		Read:   resourceNetworkingVIPAssociateV2Read,
+		Delete: resourceNetworkingVIPAssociateV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkingVIPAssociateV2Import,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"vip_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"port_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"vip_subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vip_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingVIPAssociateV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	vipID := d.Get("vip_id").(string)
+	portIDs := expandNetworkingVIPPortIDs(d.Get("port_ids").(*schema.Set))
+	if err := updateNetworkingVIPAssociate(networkingClient, vipID, portIDs, nil); err != nil {
+		return err
+	}
+
+	d.SetId(vipID)
+	return resourceNetworkingVIPAssociateV2Read(d, meta)
+}
+
+func resourceNetworkingVIPAssociateV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	if d.HasChange("port_ids") {
+		o, n := d.GetChange("port_ids")
+		portIDs := expandNetworkingVIPPortIDs(n.(*schema.Set))
+		removed := expandNetworkingVIPPortIDs(o.(*schema.Set).Difference(n.(*schema.Set)))
+		if err := updateNetworkingVIPAssociate(networkingClient, d.Id(), portIDs, removed); err != nil {
+			return err
+		}
+	}
+
+	return resourceNetworkingVIPAssociateV2Read(d, meta)
+}
+
+func resourceNetworkingVIPAssociateV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	vip, err := ports.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Network VIP")
+	}
+
+	// the associated ports are the ports of the VIP network whose fixed IPs
+	// are listed in the allowed address pairs of the VIP
+	vipPairs := make(map[string]bool)
+	for _, pair := range vip.AllowedAddressPairs {
+		vipPairs[pair.IPAddress] = true
+	}
+
+	allPages, err := ports.List(networkingClient, ports.ListOpts{NetworkID: vip.NetworkID}).AllPages()
+	if err != nil {
+		return fmt.Errorf("Error listing the ports of network %s: %s", vip.NetworkID, err)
+	}
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return fmt.Errorf("Error extracting the ports of network %s: %s", vip.NetworkID, err)
+	}
+
+	portIDs := make([]string, 0)
+	addresses := make([]string, 0)
+	for _, p := range allPorts {
+		if p.ID == vip.ID || len(p.FixedIPs) == 0 || !vipPairs[p.FixedIPs[0].IPAddress] {
+			continue
+		}
+		portIDs = append(portIDs, p.ID)
+		addresses = append(addresses, p.FixedIPs[0].IPAddress)
+	}
+
+	if len(portIDs) == 0 {
+		log.Printf("[WARN] No port is associated with the VIP %s", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("vip_id", vip.ID)
+	if len(vip.FixedIPs) > 0 {
+		d.Set("vip_subnet_id", vip.FixedIPs[0].SubnetID)
+		d.Set("vip_ip_address", vip.FixedIPs[0].IPAddress)
+	}
+	d.Set("port_ids", portIDs)
+	d.Set("ip_addresses", addresses)
+
+	return nil
+}
+
+func resourceNetworkingVIPAssociateV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	portIDs := expandNetworkingVIPPortIDs(d.Get("port_ids").(*schema.Set))
+	if err := updateNetworkingVIPAssociate(networkingClient, d.Id(), nil, portIDs); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			log.Printf("[WARN] The VIP %s is already deleted", d.Id())
+		} else {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceNetworkingVIPAssociateV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("vip_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateNetworkingVIPAssociate associates the VIP with the ports, and removes
+// the association with the removed ports.
+func updateNetworkingVIPAssociate(client *golangsdk.ServiceClient, vipID string, portIDs, removed []string) error {
+	vip, err := ports.Get(client, vipID).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return err
+		}
+		return fmt.Errorf("Error fetching vip %s: %s", vipID, err)
+	}
+	if len(vip.FixedIPs) == 0 {
+		return fmt.Errorf("vip %s has no IP address", vipID)
+	}
+	vipAddress := vip.FixedIPs[0].IPAddress
+
+	// the ports are checked before any of them is changed
+	members := make([]*ports.Port, len(portIDs))
+	for i, portID := range portIDs {
+		port, err := ports.Get(client, portID).Extract()
+		if err != nil {
+			return fmt.Errorf("Error fetching port %s: %s", portID, err)
+		}
+		if len(port.FixedIPs) == 0 {
+			return fmt.Errorf("port %s has no IP address, Error associate it", portID)
+		}
+		members[i] = port
+	}
+
+	vipPairs := make([]ports.AddressPair, len(members))
+	for i, port := range members {
+		vipPairs[i] = ports.AddressPair{IPAddress: port.FixedIPs[0].IPAddress}
+	}
+	log.Printf("[DEBUG] Associate VIP %s with the addresses %v", vipID, vipPairs)
+	_, err = ports.Update(client, vipID, ports.UpdateOpts{AllowedAddressPairs: &vipPairs}).Extract()
+	if err != nil {
+		return fmt.Errorf("Error associate vip: %s", err)
+	}
+
+	for _, port := range members {
+		if networkingPortHasAddressPair(port, vipAddress) {
+			continue
+		}
+		pairs := append(port.AllowedAddressPairs, ports.AddressPair{IPAddress: vipAddress})
+		log.Printf("[DEBUG] Add the address pair of VIP %s to port %s", vipAddress, port.ID)
+		_, err = ports.Update(client, port.ID, ports.UpdateOpts{AllowedAddressPairs: &pairs}).Extract()
+		if err != nil {
+			return fmt.Errorf("Error update port %s: %s", port.ID, err)
+		}
+	}
+
+	for _, portID := range removed {
+		port, err := ports.Get(client, portID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error fetching port %s: %s", portID, err)
+		}
+		if !networkingPortHasAddressPair(port, vipAddress) {
+			continue
+		}
+
+		pairs := make([]ports.AddressPair, 0, len(port.AllowedAddressPairs))
+		for _, pair := range port.AllowedAddressPairs {
+			if pair.IPAddress != vipAddress {
+				pairs = append(pairs, pair)
+			}
+		}
+		log.Printf("[DEBUG] Remove the address pair of VIP %s from port %s", vipAddress, portID)
+		_, err = ports.Update(client, portID, ports.UpdateOpts{AllowedAddressPairs: &pairs}).Extract()
+		if err != nil {
+			return fmt.Errorf("Error update port %s: %s", portID, err)
+		}
+	}
+
+	return nil
+}
+
+func networkingPortHasAddressPair(port *ports.Port, address string) bool {
+	for _, pair := range port.AllowedAddressPairs {
+		if pair.IPAddress == address {
+			return true
+		}
+	}
+	return false
+}
+
+func expandNetworkingVIPPortIDs(s *schema.Set) []string {
+	portIDs := make([]string, s.Len())
+	for i, raw := range s.List() {
+		portIDs[i] = raw.(string)
+	}
+	return portIDs
+}
@@ -0,0 +1,158 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccNetworkingV2VIPAssociate_basic(t *testing.T) {
+	var vip ports.Port
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_networking_vip_associate.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2VIPAssociateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2VIPAssociate_basic(rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2VIPExists("sbercloud_networking_vip.test", &vip),
+					resource.TestCheckResourceAttr(resourceName, "port_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "vip_ip_address",
+						"sbercloud_networking_vip.test", "ip_address"),
+					testAccCheckNetworkingV2VIPAssociated("sbercloud_compute_instance.test.0", &vip),
+					testAccCheckNetworkingV2VIPAssociated("sbercloud_compute_instance.test.1", &vip),
+					resource.TestCheckResourceAttrPair("sbercloud_networking_eip_associate.test", "port_id",
+						"sbercloud_networking_vip.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNetworkingV2VIPAssociate_basic(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "port_ids.#", "1"),
+					testAccCheckNetworkingV2VIPAssociated("sbercloud_compute_instance.test.0", &vip),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2VIPAssociateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_networking_vip_associate" {
+			continue
+		}
+
+		vip, err := ports.Get(networkingClient, rs.Primary.Attributes["vip_id"]).Extract()
+		if err == nil && len(vip.AllowedAddressPairs) > 0 {
+			return fmt.Errorf("VIP %s is still associated", vip.ID)
+		}
+	}
+
+	return nil
+}
+
+// testAccCheckNetworkingV2VIPAssociated checks that the VIP and the port of
+// the instance list each other in the allowed address pairs.
+func testAccCheckNetworkingV2VIPAssociated(n string, vip *ports.Port) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := ports.Get(networkingClient, vip.ID).Extract()
+		if err != nil {
+			return err
+		}
+		port, err := ports.Get(networkingClient, rs.Primary.Attributes["network.0.port"]).Extract()
+		if err != nil {
+			return err
+		}
+
+		if !networkingPortHasAddressPair(found, port.FixedIPs[0].IPAddress) {
+			return fmt.Errorf("VIP %s is not associated with port %s", vip.ID, port.ID)
+		}
+		if !networkingPortHasAddressPair(port, found.FixedIPs[0].IPAddress) {
+			return fmt.Errorf("Port %s has no address pair of VIP %s", port.ID, vip.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNetworkingV2VIPAssociate_basic(rName string, count int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_compute_instance" "test" {
+  count = 2
+
+  name              = "%s-${count.index}"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+
+resource "sbercloud_networking_vip" "test" {
+  name       = "%s"
+  network_id = data.sbercloud_vpc_subnet.test.id
+}
+
+resource "sbercloud_networking_vip_associate" "test" {
+  vip_id   = sbercloud_networking_vip.test.id
+  port_ids = slice(sbercloud_compute_instance.test[*].network[0].port, 0, %d)
+}
+
+resource "sbercloud_vpc_eip" "test" {
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name        = "%s"
+    size        = 5
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+}
+
+resource "sbercloud_networking_eip_associate" "test" {
+  public_ip = sbercloud_vpc_eip.test.address
+  port_id   = sbercloud_networking_vip.test.id
+}
+`, testAccCompute_data, rName, rName, count, rName)
+}
@@ -0,0 +1,127 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccNetworkingV2VIP_basic(t *testing.T) {
+	var vip ports.Port
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	updateName := rName + "-update"
+	resourceName := "sbercloud_networking_vip.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2VIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2VIP_basic(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2VIPExists(resourceName, &vip),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "ip_address", "192.168.0.100"),
+					resource.TestCheckResourceAttr(resourceName, "device_owner", "neutron:VIP_PORT"),
+					resource.TestCheckResourceAttrSet(resourceName, "subnet_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "mac_address"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNetworkingV2VIP_basic(rName, updateName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2VIPExists(resourceName, &vip),
+					resource.TestCheckResourceAttr(resourceName, "name", updateName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2VIPDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_networking_vip" {
+			continue
+		}
+
+		_, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("VIP still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingV2VIPExists(n string, vip *ports.Port) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VIP not found")
+		}
+
+		*vip = *found
+
+		return nil
+	}
+}
+
+func testAccNetworkingV2VIP_basic(rName, vipName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "test" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_subnet" "test" {
+  vpc_id     = sbercloud_vpc.test.id
+  name       = "%s"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+}
+
+resource "sbercloud_networking_vip" "test" {
+  name       = "%s"
+  network_id = sbercloud_vpc_subnet.test.id
+  ip_address = "192.168.0.100"
+}
+`, rName, rName, vipName)
+}
@@ -0,0 +1,374 @@
+package sbercloud
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var obsNotificationEvents = []string{
+	"ObjectCreated:*", "ObjectCreated:Put", "ObjectCreated:Post", "ObjectCreated:Copy",
+	"ObjectCreated:CompleteMultipartUpload", "ObjectRemoved:*", "ObjectRemoved:Delete",
+	"ObjectRemoved:DeleteMarkerCreated",
+}
+
+// obsNotificationConfiguration is the event notification configuration of a
+// bucket. The OBS SDK only supports the SMN topic targets.
+type obsNotificationConfiguration struct {
+	XMLName                     xml.Name                        `xml:"NotificationConfiguration"`
+	TopicConfigurations         []obsNotificationTarget         `xml:"TopicConfiguration"`
+	FunctionGraphConfigurations []obsFunctionNotificationTarget `xml:"FunctionGraphConfiguration"`
+}
+
+type obsNotificationTarget struct {
+	ID          string                  `xml:"Id,omitempty"`
+	FilterRules []obsNotificationFilter `xml:"Filter>Object>FilterRule"`
+	Topic       string                  `xml:"Topic"`
+	Events      []string                `xml:"Event"`
+}
+
+type obsFunctionNotificationTarget struct {
+	ID            string                  `xml:"Id,omitempty"`
+	FilterRules   []obsNotificationFilter `xml:"Filter>Object>FilterRule"`
+	FunctionGraph string                  `xml:"FunctionGraph"`
+	Events        []string                `xml:"Event"`
+}
+
+type obsNotificationFilter struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+func obsNotificationTargetSchema(urnKey string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			urnKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(obsNotificationEvents, false),
+				},
+				Set: schema.HashString,
+			},
+			"filter_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filter_suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func ResourceObsBucketNotification() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceObsBucketNotificationPut,
+		Read:   resourceObsBucketNotificationRead,
+		Update: resourceObsBucketNotificationPut,
+		Delete: resourceObsBucketNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"topic": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         obsNotificationTargetSchema("topic_urn"),
+				AtLeastOneOf: []string{"topic", "function"},
+			},
+			"function": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     obsNotificationTargetSchema("function_urn"),
+			},
+		},
+	}
+}
+
+func expandObsNotificationFilters(raw map[string]interface{}) []obsNotificationFilter {
+	var filters []obsNotificationFilter
+	if v := raw["filter_prefix"].(string); v != "" {
+		filters = append(filters, obsNotificationFilter{Name: "prefix", Value: v})
+	}
+	if v := raw["filter_suffix"].(string); v != "" {
+		filters = append(filters, obsNotificationFilter{Name: "suffix", Value: v})
+	}
+	return filters
+}
+
+func flattenObsNotificationTarget(id, urnKey, urn string, events []string, filters []obsNotificationFilter) map[string]interface{} {
+	target := map[string]interface{}{
+		"id":   id,
+		urnKey: urn,
+	}
+
+	eventList := make([]interface{}, len(events))
+	for i, event := range events {
+		eventList[i] = event
+	}
+	target["events"] = schema.NewSet(schema.HashString, eventList)
+
+	for _, filter := range filters {
+		switch strings.ToLower(filter.Name) {
+		case "prefix":
+			target["filter_prefix"] = filter.Value
+		case "suffix":
+			target["filter_suffix"] = filter.Value
+		}
+	}
+	return target
+}
+
+func buildObsNotificationConfiguration(d *schema.ResourceData) *obsNotificationConfiguration {
+	var notification obsNotificationConfiguration
+
+	for _, raw := range d.Get("topic").([]interface{}) {
+		t := raw.(map[string]interface{})
+		notification.TopicConfigurations = append(notification.TopicConfigurations, obsNotificationTarget{
+			ID:          t["id"].(string),
+			Topic:       t["topic_urn"].(string),
+			Events:      utils.ExpandToStringList(t["events"].(*schema.Set).List()),
+			FilterRules: expandObsNotificationFilters(t),
+		})
+	}
+
+	for _, raw := range d.Get("function").([]interface{}) {
+		f := raw.(map[string]interface{})
+		notification.FunctionGraphConfigurations = append(notification.FunctionGraphConfigurations,
+			obsFunctionNotificationTarget{
+				ID:            f["id"].(string),
+				FunctionGraph: f["function_urn"].(string),
+				Events:        utils.ExpandToStringList(f["events"].(*schema.Set).List()),
+				FilterRules:   expandObsNotificationFilters(f),
+			})
+	}
+
+	return &notification
+}
+
+// smnTopicPolicy is the access policy of an SMN topic.
+type smnTopicPolicy struct {
+	Statement []struct {
+		Effect    string      `json:"Effect"`
+		Principal interface{} `json:"Principal"`
+		Action    interface{} `json:"Action"`
+	} `json:"Statement"`
+}
+
+// policyValues returns the values of a policy element, which is either a
+// string or a list of strings.
+func policyValues(raw interface{}) []string {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func smnPolicyAllowsObsPublish(policy *smnTopicPolicy) bool {
+	for _, statement := range policy.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		canPublish := false
+		for _, action := range policyValues(statement.Action) {
+			if action == "*" || strings.EqualFold(action, "SMN:*") || strings.EqualFold(action, "SMN:Publish") {
+				canPublish = true
+			}
+		}
+
+		isObs := false
+		if principal, ok := statement.Principal.(map[string]interface{}); ok {
+			for _, service := range policyValues(principal["Service"]) {
+				if strings.EqualFold(service, "obs") {
+					isObs = true
+				}
+			}
+			for _, csp := range policyValues(principal["CSP"]) {
+				if csp == "*" {
+					isObs = true
+				}
+			}
+		} else {
+			for _, p := range policyValues(statement.Principal) {
+				if p == "*" {
+					isObs = true
+				}
+			}
+		}
+
+		if canPublish && isObs {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSmnTopicAllowsObs checks that the access policy of the topic allows OBS
+// to publish messages, otherwise OBS rejects the notification configuration.
+func checkSmnTopicAllowsObs(client *golangsdk.ServiceClient, topicURN string) error {
+	var rst golangsdk.Result
+	url := client.ServiceURL("topics", topicURN, "attributes") + "?name=access_policy"
+	_, rst.Err = client.Get(url, &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if rst.Err != nil {
+		return fmt.Errorf("Error getting access policy of SMN topic %s: %s", topicURN, rst.Err)
+	}
+
+	var attributes struct {
+		Attributes struct {
+			AccessPolicy string `json:"access_policy"`
+		} `json:"attributes"`
+	}
+	if err := rst.ExtractInto(&attributes); err != nil {
+		return fmt.Errorf("Error extracting access policy of SMN topic %s: %s", topicURN, err)
+	}
+
+	var policy smnTopicPolicy
+	if raw := attributes.Attributes.AccessPolicy; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &policy); err != nil {
+			return fmt.Errorf("Error parsing access policy of SMN topic %s: %s", topicURN, err)
+		}
+	}
+	if !smnPolicyAllowsObsPublish(&policy) {
+		return fmt.Errorf("The access policy of SMN topic %s does not allow OBS to publish messages, "+
+			"grant SMN:Publish to the obs service in the topic policy", topicURN)
+	}
+	return nil
+}
+
+func resourceObsBucketNotificationPut(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	bucket := d.Get("bucket").(string)
+
+	notification := buildObsNotificationConfiguration(d)
+	if len(notification.TopicConfigurations) > 0 {
+		smnClient, err := config.SmnV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud SMN client: %s", err)
+		}
+		for _, topic := range notification.TopicConfigurations {
+			if err := checkSmnTopicAllowsObs(smnClient, topic.Topic); err != nil {
+				return err
+			}
+		}
+	}
+
+	body, err := xml.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] set notification of OBS bucket %s: %s", bucket, body)
+
+	err = obsBucketRequest(config, region, "PUT", bucket, "notification", nil, body, nil)
+	if err != nil {
+		return getObsError("Error setting notification of OBS bucket", bucket, err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketNotificationRead(d, meta)
+}
+
+func resourceObsBucketNotificationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+
+	bucket := d.Id()
+	var notification obsNotificationConfiguration
+	err := obsBucketRequest(config, region, "GET", bucket, "notification", nil, nil, &notification)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			log.Printf("[WARN] OBS bucket %s not found", bucket)
+			d.SetId("")
+			return nil
+		}
+		return getObsError("Error getting notification of OBS bucket", bucket, err)
+	}
+	log.Printf("[DEBUG] getting notification of OBS bucket %s: %#v", bucket, notification)
+
+	topics := make([]map[string]interface{}, len(notification.TopicConfigurations))
+	for i, t := range notification.TopicConfigurations {
+		topics[i] = flattenObsNotificationTarget(t.ID, "topic_urn", t.Topic, t.Events, t.FilterRules)
+	}
+	functions := make([]map[string]interface{}, len(notification.FunctionGraphConfigurations))
+	for i, f := range notification.FunctionGraphConfigurations {
+		functions[i] = flattenObsNotificationTarget(f.ID, "function_urn", f.FunctionGraph, f.Events, f.FilterRules)
+	}
+
+	d.Set("region", region)
+	d.Set("bucket", bucket)
+	if err := d.Set("topic", topics); err != nil {
+		return fmt.Errorf("Error saving topic notifications of OBS bucket %s: %s", bucket, err)
+	}
+	if err := d.Set("function", functions); err != nil {
+		return fmt.Errorf("Error saving function notifications of OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func resourceObsBucketNotificationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+
+	// an empty configuration removes all the notifications of the bucket
+	bucket := d.Id()
+	body, err := xml.Marshal(obsNotificationConfiguration{})
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] delete notification of OBS bucket: %s", bucket)
+
+	err = obsBucketRequest(config, GetRegion(d, config), "PUT", bucket, "notification", nil, body, nil)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			return nil
+		}
+		return getObsError("Error deleting notification of OBS bucket", bucket, err)
+	}
+
+	return nil
+}
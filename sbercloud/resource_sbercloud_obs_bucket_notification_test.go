@@ -0,0 +1,250 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	testObsNotificationTopic    = "urn:smn:ru-moscow-1:0970dd7a1300f5672ff2c003c60ae115:obs-events"
+	testObsNotificationFunction = "urn:fss:ru-moscow-1:0970dd7a1300f5672ff2c003c60ae115:function:default:cleanup:latest"
+
+	testObsTopicPolicy = `{"Version":"2016-09-07","Id":"__default_policy_ID","Statement":[{"Sid":"__service_pub_0",` +
+		`"Effect":"Allow","Principal":{"Service":["obs"]},"Action":["SMN:Publish","SMN:QueryTopicDetail"],` +
+		`"Resource":"urn:smn:ru-moscow-1:0970dd7a1300f5672ff2c003c60ae115:obs-events"}]}`
+)
+
+func TestObsBucketNotification_stub(t *testing.T) {
+	stub := newAPIStub("stub-bucket")
+	stub.topicPolicies[testObsNotificationTopic] = testObsTopicPolicy
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	r := ResourceObsBucketNotification()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"bucket": "stub-bucket",
+		"topic": []interface{}{
+			map[string]interface{}{
+				"topic_urn":     testObsNotificationTopic,
+				"events":        []interface{}{"ObjectCreated:*"},
+				"filter_prefix": "images/",
+				"filter_suffix": ".jpg",
+			},
+		},
+		"function": []interface{}{
+			map[string]interface{}{
+				"function_urn": testObsNotificationFunction,
+				"events":       []interface{}{"ObjectRemoved:Delete", "ObjectRemoved:DeleteMarkerCreated"},
+			},
+		},
+	})
+
+	if err := resourceObsBucketNotificationPut(d, config); err != nil {
+		t.Fatalf("Error creating notification: %s", err)
+	}
+	if d.Id() != "stub-bucket" {
+		t.Fatalf("Expected the ID stub-bucket, got %q", d.Id())
+	}
+	body, ok := stub.subResource("stub-bucket", "notification")
+	if !ok {
+		t.Fatal("The notification was not set on the bucket")
+	}
+	for _, expected := range []string{
+		"<Topic>" + testObsNotificationTopic + "</Topic>",
+		"<FunctionGraph>" + testObsNotificationFunction + "</FunctionGraph>",
+		"<FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>",
+		"<Event>ObjectRemoved:DeleteMarkerCreated</Event>",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("Expected %s in the notification, got %s", expected, body)
+		}
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("stub-bucket")
+	if err := resourceObsBucketNotificationRead(imported, config); err != nil {
+		t.Fatalf("Error reading notification: %s", err)
+	}
+	if v := imported.Get("topic.0.filter_suffix").(string); v != ".jpg" {
+		t.Fatalf("Expected the suffix .jpg, got %q", v)
+	}
+	if v := imported.Get("function.0.events").(*schema.Set).Len(); v != 2 {
+		t.Fatalf("Expected 2 function events, got %d", v)
+	}
+
+	d.Set("function", nil)
+	d.Set("topic", []interface{}{
+		map[string]interface{}{
+			"topic_urn": testObsNotificationTopic,
+			"events":    []interface{}{"ObjectCreated:Put", "ObjectRemoved:*"},
+		},
+	})
+	if err := resourceObsBucketNotificationPut(d, config); err != nil {
+		t.Fatalf("Error updating notification: %s", err)
+	}
+	body, _ = stub.subResource("stub-bucket", "notification")
+	if strings.Contains(string(body), "FunctionGraph") || strings.Contains(string(body), "FilterRule") {
+		t.Fatalf("The function and the filters are not removed: %s", body)
+	}
+	if v := d.Get("topic.0.events").(*schema.Set).Len(); v != 2 {
+		t.Fatalf("Expected 2 topic events, got %d", v)
+	}
+
+	if err := resourceObsBucketNotificationDelete(d, config); err != nil {
+		t.Fatalf("Error deleting notification: %s", err)
+	}
+	body, _ = stub.subResource("stub-bucket", "notification")
+	if strings.Contains(string(body), "TopicConfiguration") {
+		t.Fatalf("The notification is not removed: %s", body)
+	}
+}
+
+func TestObsBucketNotification_topicPolicy(t *testing.T) {
+	stub := newAPIStub("stub-bucket")
+	stub.topicPolicies[testObsNotificationTopic] = strings.Replace(testObsTopicPolicy, `"obs"`, `"dms"`, 1)
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, ResourceObsBucketNotification().Schema, map[string]interface{}{
+		"bucket": "stub-bucket",
+		"topic": []interface{}{
+			map[string]interface{}{
+				"topic_urn": testObsNotificationTopic,
+				"events":    []interface{}{"ObjectCreated:*"},
+			},
+		},
+	})
+
+	err := resourceObsBucketNotificationPut(d, config)
+	if err == nil || !regexp.MustCompile("does not allow OBS to publish").MatchString(err.Error()) {
+		t.Fatalf("Expected an error for the topic policy, got %v", err)
+	}
+	if _, ok := stub.subResource("stub-bucket", "notification"); ok {
+		t.Fatal("The notification should not be set when the topic policy does not allow OBS")
+	}
+}
+
+func TestObsBucketNotification_bucketNotFound(t *testing.T) {
+	stub := newAPIStub()
+	config, server := newAPIStubConfig(stub)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, ResourceObsBucketNotification().Schema, map[string]interface{}{})
+	d.SetId("deleted-bucket")
+	if err := resourceObsBucketNotificationRead(d, config); err != nil {
+		t.Fatalf("Error reading notification: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatal("The ID of the notification of a deleted bucket is not cleared")
+	}
+}
+
+func TestAccObsBucketNotification_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "sbercloud_obs_bucket_notification.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBSNotification(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketNotificationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketNotification_basic(rInt, `["ObjectCreated:*"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketNotificationExists(resourceName, 1),
+					resource.TestCheckResourceAttr(resourceName, "topic.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "topic.0.topic_urn", SBC_SMN_TOPIC_URN),
+					resource.TestCheckResourceAttr(resourceName, "topic.0.filter_prefix", "images/"),
+					resource.TestCheckResourceAttr(resourceName, "topic.0.events.#", "1"),
+				),
+			},
+			{
+				Config: testAccObsBucketNotification_basic(rInt, `["ObjectCreated:Put", "ObjectRemoved:*"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketNotificationExists(resourceName, 1),
+					resource.TestCheckResourceAttr(resourceName, "topic.0.events.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketNotificationTopics(bucket string) (int, error) {
+	config := testAccProvider.Meta().(*config.Config)
+
+	var notification obsNotificationConfiguration
+	err := obsBucketRequest(config, SBC_REGION_NAME, "GET", bucket, "notification", nil, nil, &notification)
+	if err != nil {
+		return 0, err
+	}
+	return len(notification.TopicConfigurations), nil
+}
+
+func testAccCheckObsBucketNotificationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_obs_bucket_notification" {
+			continue
+		}
+
+		topics, err := testAccObsBucketNotificationTopics(rs.Primary.ID)
+		if err != nil {
+			// the bucket is deleted with the notification
+			continue
+		}
+		if topics > 0 {
+			return fmt.Errorf("The notification of OBS bucket %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckObsBucketNotificationExists(n string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		topics, err := testAccObsBucketNotificationTopics(rs.Primary.ID)
+		if err != nil {
+			return getObsError("Error getting notification of OBS bucket", rs.Primary.ID, err)
+		}
+		if topics != expected {
+			return fmt.Errorf("Expected %d topic notifications of OBS bucket %s, got %d", expected, rs.Primary.ID, topics)
+		}
+		return nil
+	}
+}
+
+func testAccObsBucketNotification_basic(randInt int, events string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "test" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "private"
+}
+
+resource "sbercloud_obs_bucket_notification" "test" {
+  bucket = sbercloud_obs_bucket.test.bucket
+
+  topic {
+    topic_urn     = "%s"
+    events        = %s
+    filter_prefix = "images/"
+  }
+}
+`, randInt, SBC_SMN_TOPIC_URN, events)
+}
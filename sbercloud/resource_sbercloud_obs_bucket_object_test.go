@@ -163,13 +163,6 @@ func testAccCheckObsBucketObjectExists(n string) resource.TestCheckFunc {
 	}
 }
 
-func getObsError(action string, bucket string, err error) error {
-	if obsError, ok := err.(obs.ObsError); ok {
-		return fmt.Errorf("%s %s: %s,\n Reason: %s", action, bucket, obsError.Code, obsError.Message)
-	}
-	return err
-}
-
 func testAccObsBucketObjectConfigSource(randInt int, source string) string {
 	return fmt.Sprintf(`
 resource "sbercloud_obs_bucket" "object_bucket" {
@@ -0,0 +1,739 @@
+package sbercloud
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// obsDeleteObjectsLimit is the maximum number of objects in a DeleteObjects request
+const obsDeleteObjectsLimit = 1000
+
+// obsLocalFile is a file of the source directory of sbercloud_obs_bucket_objects
+type obsLocalFile struct {
+	Path        string
+	Source      string
+	Size        int64
+	MD5         string
+	ContentType string
+}
+
+func ResourceObsBucketObjects() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceObsBucketObjectsPut,
+		Read:   resourceObsBucketObjectsRead,
+		Update: resourceObsBucketObjectsPut,
+		Delete: resourceObsBucketObjectsDelete,
+
+		CustomizeDiff: resourceObsBucketObjectsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"private", "public-read", "public-read-write",
+				}, true),
+			},
+			"storage_class": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"STANDARD", "WARM", "COLD",
+				}, true),
+			},
+			"encryption": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"content_types": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"delete_orphaned": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"multipart_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      64 * 1024 * 1024,
+				ValidateFunc: validation.IntAtLeast(obs.MIN_PART_SIZE),
+			},
+			"part_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      16 * 1024 * 1024,
+				ValidateFunc: validation.IntBetween(obs.MIN_PART_SIZE, obs.MAX_PART_SIZE),
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 64),
+			},
+			"files": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"md5": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceObsBucketObjectsCustomizeDiff compares the source directory with the
+// manifest in the state, so that the changed files are planned as an update.
+func resourceObsBucketObjectsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("source") || !d.NewValueKnown("content_types") {
+		return nil
+	}
+
+	files, err := scanObsSourceDirectory(d.Get("source").(string), d.Get("content_types").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	manifest := expandObsObjectsManifest(d.Get("files").([]interface{}))
+	prefix := d.Get("prefix").(string)
+	changed := len(files) != len(manifest)
+	for _, file := range files {
+		entry, ok := manifest[prefix+file.Source]
+		if !ok || entry["md5"] != file.MD5 || entry["content_type"] != file.ContentType {
+			changed = true
+			break
+		}
+	}
+
+	if changed {
+		log.Printf("[DEBUG] files in %s differ from the objects in OBS bucket", d.Get("source"))
+		return d.SetNewComputed("files")
+	}
+	return nil
+}
+
+// scanObsSourceDirectory walks the directory and returns its regular files in
+// lexical order of their slash-separated paths relative to the directory.
+func scanObsSourceDirectory(dir string, contentTypes map[string]interface{}) ([]obsLocalFile, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source directory %s: %s", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("The source %s is not a directory", dir)
+	}
+
+	var files []obsLocalFile
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// follow the symbolic links to files
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(path); err != nil {
+				return err
+			}
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		file := obsLocalFile{
+			Path:   path,
+			Source: filepath.ToSlash(rel),
+			Size:   info.Size(),
+		}
+		if file.MD5, file.ContentType, err = readObsLocalFile(path, contentTypes); err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source directory %s: %s", dir, err)
+	}
+
+	return files, nil
+}
+
+// readObsLocalFile returns the hex MD5 and the content type of a file. The
+// content type is looked up by the file extension, first in contentTypes and
+// then in the MIME types known to the system, and detected from the content of
+// the file otherwise.
+func readObsLocalFile(path string, contentTypes map[string]interface{}) (string, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", "", err
+	}
+	head = head[:n]
+
+	hash := md5.New()
+	hash.Write(head)
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", "", err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	contentType := mime.TypeByExtension(ext)
+	for k, v := range contentTypes {
+		if strings.ToLower(k) == ext {
+			contentType = v.(string)
+		}
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), contentType, nil
+}
+
+// expandObsObjectsManifest returns the entries of the files attribute by key
+func expandObsObjectsManifest(files []interface{}) map[string]map[string]interface{} {
+	manifest := make(map[string]map[string]interface{}, len(files))
+	for _, raw := range files {
+		if entry, ok := raw.(map[string]interface{}); ok {
+			manifest[entry["key"].(string)] = entry
+		}
+	}
+	return manifest
+}
+
+func flattenObsObjectsManifest(manifest map[string]map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(manifest))
+	for k := range manifest {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	files := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		files = append(files, manifest[k])
+	}
+	return files
+}
+
+// listObsObjects returns the objects under the prefix by key
+func listObsObjects(obsClient *obs.ObsClient, bucket, prefix string) (map[string]obs.Content, error) {
+	objects := make(map[string]obs.Content)
+
+	input := &obs.ListObjectsInput{}
+	input.Bucket = bucket
+	input.Prefix = prefix
+	for {
+		output, err := obsClient.ListObjects(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range output.Contents {
+			objects[content.Key] = content
+		}
+		if !output.IsTruncated || len(output.Contents) == 0 {
+			break
+		}
+		input.Marker = output.NextMarker
+		if input.Marker == "" {
+			input.Marker = output.Contents[len(output.Contents)-1].Key
+		}
+	}
+
+	return objects, nil
+}
+
+func deleteObsObjects(obsClient *obs.ObsClient, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += obsDeleteObjectsLimit {
+		end := start + obsDeleteObjectsLimit
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		input := &obs.DeleteObjectsInput{
+			Bucket: bucket,
+			Quiet:  true,
+		}
+		for _, key := range keys[start:end] {
+			input.Objects = append(input.Objects, obs.ObjectToDelete{Key: key})
+		}
+		log.Printf("[DEBUG] delete %d objects of OBS bucket %s", len(input.Objects), bucket)
+
+		output, err := obsClient.DeleteObjects(input)
+		if err != nil {
+			return err
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("Error deleting object %s: %s", output.Errors[0].Key, output.Errors[0].Message)
+		}
+	}
+	return nil
+}
+
+// obsUploadOptions holds the upload arguments, which are read before the
+// upload workers start as the ResourceData is not safe for concurrent use.
+type obsUploadOptions struct {
+	Bucket       string
+	Prefix       string
+	ACL          string
+	StorageClass string
+	Encryption   bool
+	KMSKeyID     string
+	Threshold    int64
+	PartSize     int64
+	Parallelism  int
+}
+
+func expandObsUploadOptions(d *schema.ResourceData) obsUploadOptions {
+	return obsUploadOptions{
+		Bucket:       d.Get("bucket").(string),
+		Prefix:       d.Get("prefix").(string),
+		ACL:          d.Get("acl").(string),
+		StorageClass: d.Get("storage_class").(string),
+		Encryption:   d.Get("encryption").(bool),
+		KMSKeyID:     d.Get("kms_key_id").(string),
+		Threshold:    int64(d.Get("multipart_threshold").(int)),
+		PartSize:     int64(d.Get("part_size").(int)),
+		Parallelism:  d.Get("parallelism").(int),
+	}
+}
+
+// putObsLocalFile uploads a file in a single request, or in parts uploaded by
+// parallelism tasks if the file is not smaller than the multipart threshold.
+// It returns the ETag of the object.
+func putObsLocalFile(obsClient *obs.ObsClient, opts obsUploadOptions, key string, file obsLocalFile) (string, error) {
+	operation := obs.ObjectOperationInput{
+		Bucket: opts.Bucket,
+		Key:    key,
+	}
+	if opts.ACL != "" {
+		operation.ACL = obs.AclType(opts.ACL)
+	}
+	if opts.StorageClass != "" {
+		operation.StorageClass = obs.StorageClassType(opts.StorageClass)
+	}
+	if opts.Encryption {
+		operation.SseHeader = obs.SseKmsHeader{
+			Encryption: obs.DEFAULT_SSE_KMS_ENCRYPTION,
+			Key:        opts.KMSKeyID,
+		}
+	}
+
+	if file.Size >= opts.Threshold {
+		log.Printf("[DEBUG] upload %s to OBS bucket %s in parts", file.Path, operation.Bucket)
+		return putObsLocalFileInParts(obsClient, operation, opts, file)
+	}
+
+	digest, err := hex.DecodeString(file.MD5)
+	if err != nil {
+		return "", err
+	}
+	input := &obs.PutFileInput{SourceFile: file.Path}
+	input.ObjectOperationInput = operation
+	input.ContentType = file.ContentType
+	input.ContentMD5 = base64.StdEncoding.EncodeToString(digest)
+
+	log.Printf("[DEBUG] put %s to OBS bucket %s", file.Path, operation.Bucket)
+	output, err := obsClient.PutFile(input)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(output.ETag, `"`), nil
+}
+
+// putObsLocalFileInParts uploads a file by a multipart upload, whose parts are
+// uploaded by parallelism workers. The upload is aborted if any part fails.
+// The UploadFile of the OBS client is not used as its task pool is racy.
+func putObsLocalFileInParts(obsClient *obs.ObsClient, operation obs.ObjectOperationInput, opts obsUploadOptions,
+	file obsLocalFile) (string, error) {
+	initiated, err := obsClient.InitiateMultipartUpload(&obs.InitiateMultipartUploadInput{
+		ObjectOperationInput: operation,
+		ContentType:          file.ContentType,
+	})
+	if err != nil {
+		return "", err
+	}
+	uploadID := initiated.UploadId
+
+	count := int((file.Size + opts.PartSize - 1) / opts.PartSize)
+	parts := make([]obs.Part, count)
+	partNumbers := make(chan int)
+
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Parallelism && i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range partNumbers {
+				offset := int64(number-1) * opts.PartSize
+				size := opts.PartSize
+				if offset+size > file.Size {
+					size = file.Size - offset
+				}
+				output, err := obsClient.UploadPart(&obs.UploadPartInput{
+					Bucket:     operation.Bucket,
+					Key:        operation.Key,
+					PartNumber: number,
+					UploadId:   uploadID,
+					SseHeader:  operation.SseHeader,
+					SourceFile: file.Path,
+					Offset:     offset,
+					PartSize:   size,
+				})
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					parts[number-1] = obs.Part{PartNumber: number, ETag: output.ETag}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for number := 1; number <= count; number++ {
+		partNumbers <- number
+	}
+	close(partNumbers)
+	wg.Wait()
+
+	if firstErr == nil {
+		var output *obs.CompleteMultipartUploadOutput
+		output, firstErr = obsClient.CompleteMultipartUpload(&obs.CompleteMultipartUploadInput{
+			Bucket:   operation.Bucket,
+			Key:      operation.Key,
+			UploadId: uploadID,
+			Parts:    parts,
+		})
+		if firstErr == nil {
+			return strings.Trim(output.ETag, `"`), nil
+		}
+	}
+
+	_, err = obsClient.AbortMultipartUpload(&obs.AbortMultipartUploadInput{
+		Bucket:   operation.Bucket,
+		Key:      operation.Key,
+		UploadId: uploadID,
+	})
+	if err != nil {
+		log.Printf("[WARN] Error aborting the multipart upload of %s: %s", file.Path, err)
+	}
+	return "", firstErr
+}
+
+// uploadObsLocalFiles uploads the small files by parallelism workers, and then
+// the large files one by one, each of them in parallel parts. The manifest
+// entries of the uploaded files are added to manifest, even if some of the
+// uploads fail.
+func uploadObsLocalFiles(obsClient *obs.ObsClient, opts obsUploadOptions, files []obsLocalFile,
+	manifest map[string]map[string]interface{}) error {
+	var mu sync.Mutex
+	var firstErr error
+	upload := func(file obsLocalFile) {
+		key := opts.Prefix + file.Source
+		etag, err := putObsLocalFile(obsClient, opts, key, file)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = getObsError(fmt.Sprintf("Error uploading %s to OBS bucket", file.Path),
+					opts.Bucket, err)
+			}
+			return
+		}
+		manifest[key] = map[string]interface{}{
+			"key":          key,
+			"source":       file.Source,
+			"md5":          file.MD5,
+			"etag":         etag,
+			"size":         int(file.Size),
+			"content_type": file.ContentType,
+		}
+	}
+
+	var large []obsLocalFile
+	jobs := make(chan obsLocalFile)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				upload(file)
+			}
+		}()
+	}
+	for _, file := range files {
+		if file.Size >= opts.Threshold {
+			large = append(large, file)
+			continue
+		}
+		jobs <- file
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, file := range large {
+		if firstErr != nil {
+			break
+		}
+		upload(file)
+	}
+
+	return firstErr
+}
+
+func resourceObsBucketObjectsPut(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	obsClient, err := config.ObjectStorageClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+	_, err = obsClient.HeadBucket(bucket)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			return fmt.Errorf("OBS bucket(%s) not found", bucket)
+		}
+		return fmt.Errorf("error reading OBS bucket %s: %s", bucket, err)
+	}
+
+	files, err := scanObsSourceDirectory(d.Get("source").(string), d.Get("content_types").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	remote, err := listObsObjects(obsClient, bucket, prefix)
+	if err != nil {
+		return getObsError("Error listing objects of OBS bucket", bucket, err)
+	}
+
+	// the options of the objects only take effect on upload
+	uploadAll := d.Id() != "" && d.HasChanges("acl", "storage_class", "encryption", "kms_key_id")
+	old, _ := d.GetChange("files")
+	previous := expandObsObjectsManifest(old.([]interface{}))
+	manifest := make(map[string]map[string]interface{})
+	local := make(map[string]bool)
+
+	var changed []obsLocalFile
+	for _, file := range files {
+		key := prefix + file.Source
+		local[key] = true
+
+		object, ok := remote[key]
+		if !ok || uploadAll {
+			changed = append(changed, file)
+			continue
+		}
+		etag := strings.Trim(object.ETag, `"`)
+		entry, managed := previous[key]
+		switch {
+		case managed && entry["md5"] == file.MD5 && entry["content_type"] == file.ContentType && entry["etag"] == etag:
+		case !managed && etag == file.MD5:
+		default:
+			changed = append(changed, file)
+			continue
+		}
+		manifest[key] = map[string]interface{}{
+			"key":          key,
+			"source":       file.Source,
+			"md5":          file.MD5,
+			"etag":         etag,
+			"size":         int(file.Size),
+			"content_type": file.ContentType,
+		}
+	}
+
+	// on a failed update, keep tracking the objects uploaded before the failure
+	// together with the objects which are not touched yet, so that they are
+	// still uploaded or deleted by the next apply; a failed create is dropped
+	isNew := d.Id() == ""
+	d.SetId(fmt.Sprintf("%s/%s", bucket, prefix))
+	partialFailure := func(err error) error {
+		if isNew {
+			d.SetId("")
+			return err
+		}
+		for key, entry := range previous {
+			if _, ok := manifest[key]; !ok {
+				manifest[key] = entry
+			}
+		}
+		d.Set("files", flattenObsObjectsManifest(manifest))
+		return err
+	}
+
+	log.Printf("[DEBUG] upload %d of %d files to OBS bucket %s", len(changed), len(files), bucket)
+	if err := uploadObsLocalFiles(obsClient, expandObsUploadOptions(d), changed, manifest); err != nil {
+		return partialFailure(err)
+	}
+
+	var orphaned []string
+	for key := range remote {
+		if local[key] || strings.HasSuffix(key, "/") {
+			continue
+		}
+		if _, managed := previous[key]; managed || d.Get("delete_orphaned").(bool) {
+			orphaned = append(orphaned, key)
+		}
+	}
+	sort.Strings(orphaned)
+	if err := deleteObsObjects(obsClient, bucket, orphaned); err != nil {
+		return partialFailure(getObsError("Error deleting orphaned objects of OBS bucket", bucket, err))
+	}
+
+	if err := d.Set("files", flattenObsObjectsManifest(manifest)); err != nil {
+		return fmt.Errorf("Error saving files of OBS bucket objects %s: %s", d.Id(), err)
+	}
+	return resourceObsBucketObjectsRead(d, meta)
+}
+
+func resourceObsBucketObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	obsClient, err := config.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	remote, err := listObsObjects(obsClient, bucket, d.Get("prefix").(string))
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			log.Printf("[WARN] OBS bucket %s not found", bucket)
+			d.SetId("")
+			return nil
+		}
+		return getObsError("Error listing objects of OBS bucket", bucket, err)
+	}
+
+	// drop the objects deleted outside of terraform and forget the MD5 of the
+	// objects changed outside of terraform, so they are uploaded again
+	manifest := expandObsObjectsManifest(d.Get("files").([]interface{}))
+	for key, entry := range manifest {
+		object, ok := remote[key]
+		if !ok {
+			log.Printf("[WARN] object %s not found in bucket %s", key, bucket)
+			delete(manifest, key)
+			continue
+		}
+		if etag := strings.Trim(object.ETag, `"`); etag != entry["etag"] {
+			log.Printf("[WARN] object %s in bucket %s is changed, the ETag is %s", key, bucket, etag)
+			entry["etag"] = etag
+			entry["md5"] = ""
+		}
+		entry["size"] = int(object.Size)
+	}
+
+	d.Set("region", region)
+	if err := d.Set("files", flattenObsObjectsManifest(manifest)); err != nil {
+		return fmt.Errorf("Error saving files of OBS bucket objects %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceObsBucketObjectsDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	obsClient, err := config.ObjectStorageClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	var keys []string
+	for key := range expandObsObjectsManifest(d.Get("files").([]interface{})) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	err = deleteObsObjects(obsClient, bucket, keys)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			return nil
+		}
+		return getObsError("Error deleting objects of OBS bucket", bucket, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package sbercloud
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccObsBucketObjects_basic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-acc-obs-objects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testObsBucketObjectsWriteFile(t, dir, "index.html", []byte("<html><body>index</body></html>"))
+	testObsBucketObjectsWriteFile(t, dir, "css/site.css", []byte("body { color: black; }"))
+	testObsBucketObjectsWriteFile(t, dir, "stale.txt", []byte("stale"))
+
+	rInt := acctest.RandInt()
+	resourceName := "sbercloud_obs_bucket_objects.site"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketObjectsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjects_basic(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "files.#", "3"),
+					testAccCheckObsBucketObjectsExist(resourceName, "site/index.html", "site/css/site.css", "site/stale.txt"),
+				),
+			},
+			{
+				PreConfig: func() {
+					testObsBucketObjectsWriteFile(t, dir, "css/site.css", []byte("body { color: white; }"))
+					if err := os.Remove(filepath.Join(dir, "stale.txt")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccObsBucketObjects_basic(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "files.#", "2"),
+					testAccCheckObsBucketObjectsExist(resourceName, "site/index.html", "site/css/site.css"),
+					testAccCheckObsBucketObjectsMissing(resourceName, "site/stale.txt"),
+				),
+			},
+		},
+	})
+}
+
+func testObsBucketObjectsWriteFile(t *testing.T, dir, name string, data []byte) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testAccObsBucketObjectsKeys returns the keys of the objects under the prefix
+// of the resource.
+func testAccObsBucketObjectsKeys(rs *terraform.ResourceState) (map[string]bool, error) {
+	config := testAccProvider.Meta().(*config.Config)
+	obsClient, err := config.ObjectStorageClient(SBC_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	bucket := rs.Primary.Attributes["bucket"]
+	input := &obs.ListObjectsInput{}
+	input.Bucket = bucket
+	input.Prefix = rs.Primary.Attributes["prefix"]
+
+	resp, err := obsClient.ListObjects(input)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(resp.Contents))
+	for _, content := range resp.Contents {
+		keys[content.Key] = true
+	}
+	return keys, nil
+}
+
+func testAccCheckObsBucketObjectsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_obs_bucket_objects" {
+			continue
+		}
+
+		keys, err := testAccObsBucketObjectsKeys(rs)
+		if err != nil {
+			if obsError, ok := err.(obs.ObsError); ok && obsError.Code == "NoSuchBucket" {
+				continue
+			}
+			return err
+		}
+		if len(keys) > 0 {
+			return fmt.Errorf("Objects of %s still exist in bucket %s", rs.Primary.ID, rs.Primary.Attributes["bucket"])
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckObsBucketObjectsExist(n string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		keys, err := testAccObsBucketObjectsKeys(rs)
+		if err != nil {
+			return err
+		}
+		for _, key := range expected {
+			if !keys[key] {
+				return fmt.Errorf("Object %s not found in bucket %s", key, rs.Primary.Attributes["bucket"])
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckObsBucketObjectsMissing(n string, unexpected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		keys, err := testAccObsBucketObjectsKeys(rs)
+		if err != nil {
+			return err
+		}
+		for _, key := range unexpected {
+			if keys[key] {
+				return fmt.Errorf("Object %s still exists in bucket %s", key, rs.Primary.Attributes["bucket"])
+			}
+		}
+		return nil
+	}
+}
+
+func testAccObsBucketObjects_basic(randInt int, source string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "site" {
+  bucket = "tf-objects-test-bucket-%d"
+}
+
+resource "sbercloud_obs_bucket_objects" "site" {
+  bucket          = sbercloud_obs_bucket.site.bucket
+  source          = "%s"
+  prefix          = "site/"
+  delete_orphaned = true
+}
+`, randInt, source)
+}
@@ -0,0 +1,230 @@
+package sbercloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// obsReplicationConfiguration is the cross-region replication configuration of
+// a bucket, which the OBS SDK does not support.
+type obsReplicationConfiguration struct {
+	XMLName xml.Name             `xml:"ReplicationConfiguration"`
+	Agency  string               `xml:"Agency"`
+	Rules   []obsReplicationRule `xml:"Rule"`
+}
+
+type obsReplicationRule struct {
+	ID          string `xml:"ID,omitempty"`
+	Prefix      string `xml:"Prefix"`
+	Status      string `xml:"Status"`
+	Destination struct {
+		Bucket       string `xml:"Bucket"`
+		StorageClass string `xml:"StorageClass,omitempty"`
+		DeleteData   string `xml:"DeleteData,omitempty"`
+	} `xml:"Destination"`
+	HistoricalObjectReplication string `xml:"HistoricalObjectReplication,omitempty"`
+}
+
+func ResourceObsBucketReplication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceObsBucketReplicationPut,
+		Read:   resourceObsBucketReplicationRead,
+		Update: resourceObsBucketReplicationPut,
+		Delete: resourceObsBucketReplicationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"agency": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"STANDARD", "WARM", "COLD",
+							}, false),
+						},
+						"delete_data": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"history_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildObsReplicationConfiguration(d *schema.ResourceData) *obsReplicationConfiguration {
+	replication := obsReplicationConfiguration{
+		Agency: d.Get("agency").(string),
+	}
+
+	destination := d.Get("destination_bucket").(string)
+	for _, raw := range d.Get("rule").([]interface{}) {
+		r := raw.(map[string]interface{})
+		rule := obsReplicationRule{
+			ID:     r["id"].(string),
+			Prefix: r["prefix"].(string),
+			Status: "Disabled",
+		}
+		if r["enabled"].(bool) {
+			rule.Status = "Enabled"
+		}
+		rule.Destination.Bucket = destination
+		rule.Destination.StorageClass = r["storage_class"].(string)
+		rule.Destination.DeleteData = "Disable"
+		if r["delete_data"].(bool) {
+			rule.Destination.DeleteData = "Enable"
+		}
+		rule.HistoricalObjectReplication = "Disabled"
+		if r["history_enabled"].(bool) {
+			rule.HistoricalObjectReplication = "Enabled"
+		}
+		replication.Rules = append(replication.Rules, rule)
+	}
+
+	return &replication
+}
+
+func resourceObsBucketReplicationPut(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	obsClient, err := config.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud OBS client: %s", err)
+	}
+
+	// OBS only replicates the buckets with versioning enabled
+	bucket := d.Get("bucket").(string)
+	output, err := obsClient.GetBucketVersioning(bucket)
+	if err != nil {
+		return getObsError("Error getting versioning status of OBS bucket", bucket, err)
+	}
+	if output.Status != obs.VersioningStatusEnabled {
+		return fmt.Errorf("Versioning must be enabled for the source OBS bucket %s", bucket)
+	}
+
+	replication := buildObsReplicationConfiguration(d)
+	body, err := xml.Marshal(replication)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] set replication of OBS bucket %s: %s", bucket, body)
+
+	err = obsBucketRequest(config, region, "PUT", bucket, "replication", nil, body, nil)
+	if err != nil {
+		return getObsError("Error setting replication of OBS bucket", bucket, err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketReplicationRead(d, meta)
+}
+
+func resourceObsBucketReplicationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+
+	bucket := d.Id()
+	var replication obsReplicationConfiguration
+	err := obsBucketRequest(config, region, "GET", bucket, "replication", nil, nil, &replication)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			log.Printf("[WARN] replication of OBS bucket %s not found", bucket)
+			d.SetId("")
+			return nil
+		}
+		return getObsError("Error getting replication of OBS bucket", bucket, err)
+	}
+	log.Printf("[DEBUG] getting replication of OBS bucket %s: %#v", bucket, replication)
+
+	rules := make([]map[string]interface{}, 0, len(replication.Rules))
+	for _, rule := range replication.Rules {
+		d.Set("destination_bucket", rule.Destination.Bucket)
+		rules = append(rules, map[string]interface{}{
+			"id":              rule.ID,
+			"prefix":          rule.Prefix,
+			"enabled":         rule.Status == "Enabled",
+			"storage_class":   normalizeStorageClass(rule.Destination.StorageClass),
+			"delete_data":     rule.Destination.DeleteData == "Enable",
+			"history_enabled": rule.HistoricalObjectReplication == "Enabled",
+		})
+	}
+
+	d.Set("region", region)
+	d.Set("bucket", bucket)
+	d.Set("agency", replication.Agency)
+	if err := d.Set("rule", rules); err != nil {
+		return fmt.Errorf("Error saving replication rules of OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func resourceObsBucketReplicationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] delete replication of OBS bucket: %s", bucket)
+	err := obsBucketRequest(config, GetRegion(d, config), "DELETE", bucket, "replication", nil, nil, nil)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			return nil
+		}
+		return getObsError("Error deleting replication of OBS bucket", bucket, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccObsBucketReplication_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "sbercloud_obs_bucket_replication.replication"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBSReplication(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketReplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketReplication_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "bucket", testAccObsBucketName(rInt)),
+					resource.TestCheckResourceAttr(resourceName, "destination_bucket",
+						fmt.Sprintf("tf-test-bucket-dest-%d", rInt)),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.prefix", "backup/"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.delete_data", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "rule.0.id"),
+				),
+			},
+			{
+				Config: testAccObsBucketReplication_update(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.storage_class", "WARM"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.delete_data", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.prefix", "logs/"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccObsBucketReplication_versioningDisabled(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBSReplication(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccObsBucketReplication_versioningDisabled(rInt),
+				ExpectError: regexp.MustCompile("Versioning must be enabled"),
+			},
+		},
+	})
+}
+
+func testAccCheckObsBucketReplicationDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_obs_bucket_replication" {
+			continue
+		}
+
+		err := obsBucketRequest(config, SBC_REGION_NAME, "GET", rs.Primary.ID, "replication", nil, nil, nil)
+		if err == nil {
+			return fmt.Errorf("Replication of OBS bucket %s still exists", rs.Primary.ID)
+		}
+		if obsError, ok := err.(obs.ObsError); !ok || obsError.StatusCode != 404 {
+			return err
+		}
+	}
+	return testAccCheckObsBucketDestroy(s)
+}
+
+func testAccObsBucketReplication_base(rInt int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_identity_agency" "agency" {
+  name                   = "tf-test-obs-agency-%d"
+  delegated_service_name = "op_svc_obs"
+  domain_roles           = ["OBS Administrator"]
+}
+
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket     = "tf-test-bucket-%d"
+  acl        = "private"
+  versioning = true
+}
+
+resource "sbercloud_obs_bucket" "destination" {
+  region     = "%s"
+  bucket     = "tf-test-bucket-dest-%d"
+  acl        = "private"
+  versioning = true
+}
+`, rInt, rInt, SBC_DEST_REGION, rInt)
+}
+
+func testAccObsBucketReplication_basic(rInt int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_obs_bucket_replication" "replication" {
+  bucket             = sbercloud_obs_bucket.bucket.bucket
+  destination_bucket = sbercloud_obs_bucket.destination.bucket
+  agency             = sbercloud_identity_agency.agency.name
+
+  rule {
+    prefix = "backup/"
+  }
+}
+`, testAccObsBucketReplication_base(rInt))
+}
+
+func testAccObsBucketReplication_update(rInt int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_obs_bucket_replication" "replication" {
+  bucket             = sbercloud_obs_bucket.bucket.bucket
+  destination_bucket = sbercloud_obs_bucket.destination.bucket
+  agency             = sbercloud_identity_agency.agency.name
+
+  rule {
+    prefix        = "backup/"
+    storage_class = "WARM"
+    delete_data   = true
+  }
+
+  rule {
+    prefix  = "logs/"
+    enabled = false
+  }
+}
+`, testAccObsBucketReplication_base(rInt))
+}
+
+func testAccObsBucketReplication_versioningDisabled(rInt int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "private"
+}
+
+resource "sbercloud_obs_bucket_replication" "replication" {
+  bucket             = sbercloud_obs_bucket.bucket.bucket
+  destination_bucket = "tf-test-bucket-dest-%d"
+  agency             = "tf-test-obs-agency-%d"
+
+  rule {
+    prefix = "backup/"
+  }
+}
+`, rInt, rInt, rInt)
+}
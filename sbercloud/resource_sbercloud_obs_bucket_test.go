@@ -266,6 +266,75 @@ func TestAccObsBucket_cors(t *testing.T) {
 	})
 }
 
+func TestAccObsBucket_encryption(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "sbercloud_obs_bucket.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketConfigWithEncryption(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(
+						resourceName, "encryption.0.sse_algorithm", "kms"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "encryption.0.kms_key_id", "sbercloud_kms_key.key", "id"),
+				),
+			},
+			{
+				Config: testAccObsBucket_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "encryption.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObsBucket_objectLock(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "sbercloud_obs_bucket.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckOBS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketConfigWithObjectLock(rInt, "days = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "object_lock_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "object_lock.0.mode", "COMPLIANCE"),
+					resource.TestCheckResourceAttr(resourceName, "object_lock.0.days", "1"),
+				),
+			},
+			{
+				Config: testAccObsBucketConfigWithObjectLock(rInt, "years = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "object_lock.0.days", "0"),
+					resource.TestCheckResourceAttr(resourceName, "object_lock.0.years", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"acl",
+					"force_destroy",
+				},
+			},
+		},
+	})
+}
+
 func testAccCheckObsBucketDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	obsClient, err := config.ObjectStorageClient(SBC_REGION_NAME)
@@ -542,3 +611,37 @@ resource "sbercloud_obs_bucket" "bucket" {
 }
 `, randInt)
 }
+
+func testAccObsBucketConfigWithEncryption(randInt int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_kms_key" "key" {
+  key_alias       = "tf-test-key-%d"
+  pending_days    = "7"
+}
+
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "private"
+
+  encryption {
+    sse_algorithm = "kms"
+    kms_key_id    = sbercloud_kms_key.key.id
+  }
+}
+`, randInt, randInt)
+}
+
+func testAccObsBucketConfigWithObjectLock(randInt int, retention string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_obs_bucket" "bucket" {
+  bucket     = "tf-test-bucket-%d"
+  acl        = "private"
+  versioning = true
+
+  object_lock {
+    mode = "COMPLIANCE"
+    %s
+  }
+}
+`, randInt, retention)
+}
@@ -0,0 +1,458 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/common/tags"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// vpcCreateOpts extends vpcs.CreateOpts with the description of the VPC.
+type vpcCreateOpts struct {
+	Name                string `json:"name,omitempty"`
+	CIDR                string `json:"cidr,omitempty"`
+	Description         string `json:"description,omitempty"`
+	EnterpriseProjectID string `json:"enterprise_project_id,omitempty"`
+}
+
+func (opts vpcCreateOpts) ToVpcCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "vpc")
+}
+
+// vpcUpdateOpts extends vpcs.UpdateOpts with the description of the VPC, which
+// is cleared by an empty string.
+type vpcUpdateOpts struct {
+	Name        string  `json:"name,omitempty"`
+	CIDR        string  `json:"cidr,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+func (opts vpcUpdateOpts) ToVpcUpdateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "vpc")
+}
+
+func ResourceVirtualPrivateCloudV1() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVirtualPrivateCloudV1Create,
+		Read:   resourceVirtualPrivateCloudV1Read,
+		Update: resourceVirtualPrivateCloudV1Update,
+		Delete: resourceVirtualPrivateCloudV1Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVirtualPrivateCloudV1Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(3 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{ //request and response parameters
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateString64WithChinese,
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateCIDR,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 255),
+			},
+			"secondary_cidrs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: utils.ValidateCIDR,
+				},
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"shared": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"routes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nexthop": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+// vpcV3Client returns a client of the VPC v3 API, which manages the secondary
+// CIDR blocks of the VPCs, on the endpoint of the v1 client.
+func vpcV3Client(vpcClient *golangsdk.ServiceClient) *golangsdk.ServiceClient {
+	v3Client := *vpcClient
+	v3Client.ResourceBase = strings.Replace(vpcClient.ResourceBase, "/v1/", "/v3/", 1)
+	return &v3Client
+}
+
+func getVpcSecondaryCidrs(vpcClient *golangsdk.ServiceClient, id string) ([]string, error) {
+	client := vpcV3Client(vpcClient)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(client.ServiceURL(client.ProjectID, "vpc", "vpcs", id), &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if rst.Err != nil {
+		return nil, rst.Err
+	}
+
+	var response struct {
+		Vpc struct {
+			ExtendCidrs []string `json:"extend_cidrs"`
+		} `json:"vpc"`
+	}
+	err := rst.ExtractInto(&response)
+	return response.Vpc.ExtendCidrs, err
+}
+
+// readVpcSecondaryCidrs sets secondary_cidrs, it only logs a warning if the VPC v3
+// API is not available in the region.
+func readVpcSecondaryCidrs(d *schema.ResourceData, vpcClient *golangsdk.ServiceClient) error {
+	secondaryCidrs, err := getVpcSecondaryCidrs(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			log.Printf("[WARN] The VPC v3 API is not available, secondary CIDR blocks of VPC %s are not read: %s", d.Id(), err)
+			return nil
+		}
+		if errCode, ok := err.(golangsdk.ErrUnexpectedResponseCode); ok && errCode.Actual == http.StatusNotImplemented {
+			log.Printf("[WARN] The VPC v3 API is not available, secondary CIDR blocks of VPC %s are not read: %s", d.Id(), err)
+			return nil
+		}
+		return fmt.Errorf("Error retrieving secondary CIDR blocks of SberCloud VPC %s: %s", d.Id(), err)
+	}
+	d.Set("secondary_cidrs", secondaryCidrs)
+	return nil
+}
+
+// updateVpcSecondaryCidrs adds or removes the secondary CIDR blocks of a VPC,
+// the action is add-extend-cidr or remove-extend-cidr.
+func updateVpcSecondaryCidrs(vpcClient *golangsdk.ServiceClient, id, action string, cidrs []string) error {
+	client := vpcV3Client(vpcClient)
+	body := map[string]interface{}{
+		"vpc": map[string]interface{}{
+			"extend_cidrs": cidrs,
+		},
+	}
+	_, err := client.Put(client.ServiceURL(client.ProjectID, "vpc", "vpcs", id, action), body, nil,
+		&golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	return err
+}
+
+func resourceVirtualPrivateCloudV1Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud vpc client: %s", err)
+	}
+
+	createOpts := vpcCreateOpts{
+		Name:                d.Get("name").(string),
+		CIDR:                d.Get("cidr").(string),
+		Description:         d.Get("description").(string),
+		EnterpriseProjectID: GetEnterpriseProjectID(d, config),
+	}
+	log.Printf("[DEBUG] Create VPC options: %#v", createOpts)
+
+	n, err := vpcs.Create(vpcClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC: %s", err)
+	}
+	d.SetId(n.ID)
+
+	log.Printf("[INFO] Vpc ID: %s", n.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    waitForVpcActive(vpcClient, n.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, stateErr := stateConf.WaitForState()
+	if stateErr != nil {
+		return fmt.Errorf(
+			"Error waiting for Vpc (%s) to become ACTIVE: %s",
+			n.ID, stateErr)
+	}
+
+	if v := d.Get("secondary_cidrs").(*schema.Set); v.Len() > 0 {
+		cidrs := utils.ExpandToStringList(v.List())
+		if err := updateVpcSecondaryCidrs(vpcClient, n.ID, "add-extend-cidr", cidrs); err != nil {
+			return fmt.Errorf("Error adding secondary CIDR blocks to SberCloud VPC %s: %s", n.ID, err)
+		}
+	}
+
+	//set tags
+	tagRaw := d.Get("tags").(map[string]interface{})
+	if len(tagRaw) > 0 {
+		vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud vpc client: %s", err)
+		}
+		taglist := utils.ExpandResourceTags(tagRaw)
+		if tagErr := tags.Create(vpcV2Client, "vpcs", n.ID, taglist).ExtractErr(); tagErr != nil {
+			return fmt.Errorf("Error setting tags of VirtualPrivateCloud %q: %s", n.ID, tagErr)
+		}
+	}
+
+	return resourceVirtualPrivateCloudV1Read(d, meta)
+}
+
+func resourceVirtualPrivateCloudV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc client: %s", err)
+	}
+
+	var response struct {
+		Vpc struct {
+			vpcs.Vpc
+			Description string `json:"description"`
+		} `json:"vpc"`
+	}
+	err = vpcs.Get(vpcClient, d.Id()).ExtractInto(&response)
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Vpc")
+	}
+	n := response.Vpc
+
+	d.Set("name", n.Name)
+	d.Set("cidr", n.CIDR)
+	d.Set("description", n.Description)
+	d.Set("enterprise_project_id", n.EnterpriseProjectID)
+	d.Set("status", n.Status)
+	d.Set("region", GetRegion(d, config))
+
+	// save route tables
+	routes := make([]map[string]interface{}, len(n.Routes))
+	for i, rtb := range n.Routes {
+		route := map[string]interface{}{
+			"destination": rtb.DestinationCIDR,
+			"nexthop":     rtb.NextHop,
+		}
+		routes[i] = route
+	}
+	d.Set("routes", routes)
+
+	// The VPC v3 API is not available in every region, so the secondary CIDR
+	// blocks are only refreshed when they are managed.
+	if d.Get("secondary_cidrs").(*schema.Set).Len() > 0 {
+		if err := readVpcSecondaryCidrs(d, vpcClient); err != nil {
+			return err
+		}
+	}
+
+	// save VirtualPrivateCloudV2 tags
+	if vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config)); err == nil {
+		if resourceTags, err := tags.Get(vpcV2Client, "vpcs", d.Id()).Extract(); err == nil {
+			tagmap := utils.TagsToMap(resourceTags.Tags)
+			if err := d.Set("tags", tagmap); err != nil {
+				return fmt.Errorf("Error saving tags to state for VPC (%s): %s", d.Id(), err)
+			}
+		} else {
+			log.Printf("[WARN] Error fetching tags of VPC (%s): %s", d.Id(), err)
+		}
+	} else {
+		return fmt.Errorf("Error creating vpc client: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVirtualPrivateCloudV1Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc: %s", err)
+	}
+
+	if d.HasChanges("name", "cidr", "description") {
+		updateOpts := vpcUpdateOpts{
+			Name: d.Get("name").(string),
+		}
+		if d.HasChange("cidr") {
+			updateOpts.CIDR = d.Get("cidr").(string)
+		}
+		if d.HasChange("description") {
+			description := d.Get("description").(string)
+			updateOpts.Description = &description
+		}
+
+		_, err = vpcs.Update(vpcClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud Vpc: %s", err)
+		}
+	}
+
+	// the removed CIDR blocks go first, so that a block can be replaced by an overlapping one
+	if d.HasChange("secondary_cidrs") {
+		o, n := d.GetChange("secondary_cidrs")
+		oldCidrs, newCidrs := o.(*schema.Set), n.(*schema.Set)
+
+		if removed := oldCidrs.Difference(newCidrs); removed.Len() > 0 {
+			cidrs := utils.ExpandToStringList(removed.List())
+			if err := updateVpcSecondaryCidrs(vpcClient, d.Id(), "remove-extend-cidr", cidrs); err != nil {
+				return fmt.Errorf("Error removing secondary CIDR blocks from SberCloud VPC %s: %s", d.Id(), err)
+			}
+		}
+		if added := newCidrs.Difference(oldCidrs); added.Len() > 0 {
+			cidrs := utils.ExpandToStringList(added.List())
+			if err := updateVpcSecondaryCidrs(vpcClient, d.Id(), "add-extend-cidr", cidrs); err != nil {
+				return fmt.Errorf("Error adding secondary CIDR blocks to SberCloud VPC %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	//update tags
+	if d.HasChange("tags") {
+		vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud vpc client: %s", err)
+		}
+
+		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "vpcs", d.Id())
+		if tagErr != nil {
+			return fmt.Errorf("Error updating tags of VPC %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	return resourceVirtualPrivateCloudV1Read(d, meta)
+}
+
+func resourceVirtualPrivateCloudV1Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud vpc: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForVpcDelete(vpcClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud Vpc: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceVirtualPrivateCloudV1Import also reads the secondary CIDR blocks of the
+// VPC, which the refresh only reads when they are set.
+func resourceVirtualPrivateCloudV1Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating SberCloud Vpc client: %s", err)
+	}
+
+	if err := readVpcSecondaryCidrs(d, vpcClient); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func waitForVpcActive(vpcClient *golangsdk.ServiceClient, vpcId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := vpcs.Get(vpcClient, vpcId).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if n.Status == "OK" {
+			return n, "ACTIVE", nil
+		}
+
+		//If vpc status is other than Ok, send error
+		if n.Status == "DOWN" {
+			return nil, "", fmt.Errorf("Vpc status: '%s'", n.Status)
+		}
+
+		return n, n.Status, nil
+	}
+}
+
+func waitForVpcDelete(vpcClient *golangsdk.ServiceClient, vpcId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		r, err := vpcs.Get(vpcClient, vpcId).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[INFO] Successfully deleted SberCloud vpc %s", vpcId)
+				return r, "DELETED", nil
+			}
+			return r, "ACTIVE", err
+		}
+
+		err = vpcs.Delete(vpcClient, vpcId).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[INFO] Successfully deleted SberCloud vpc %s", vpcId)
+				return r, "DELETED", nil
+			}
+			if errCode, ok := err.(golangsdk.ErrUnexpectedResponseCode); ok {
+				if errCode.Actual == 409 {
+					return r, "ACTIVE", nil
+				}
+			}
+			return r, "ACTIVE", err
+		}
+
+		return r, "ACTIVE", nil
+	}
+}
@@ -0,0 +1,219 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// vpcAddressGroup is an IP address group of the VPC v3 API, which can be
+// referenced by the security group rules instead of a CIDR block.
+type vpcAddressGroup struct {
+	ID                  string   `json:"id,omitempty"`
+	Name                string   `json:"name,omitempty"`
+	Description         *string  `json:"description,omitempty"`
+	IPVersion           int      `json:"ip_version,omitempty"`
+	IPSet               []string `json:"ip_set,omitempty"`
+	EnterpriseProjectID string   `json:"enterprise_project_id,omitempty"`
+	CreatedAt           string   `json:"created_at,omitempty"`
+	UpdatedAt           string   `json:"updated_at,omitempty"`
+}
+
+var vpcAddressGroupNameRegexp = regexp.MustCompile(`^[\p{Han}\w.-]*$`)
+
+func ResourceVpcAddressGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcAddressGroupCreate,
+		Read:   resourceVpcAddressGroupRead,
+		Update: resourceVpcAddressGroupUpdate,
+		Delete: resourceVpcAddressGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 64),
+					validation.StringMatch(vpcAddressGroupNameRegexp,
+						"only letters, digits, underscores (_), hyphens (-) and periods (.) are allowed"),
+				),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 255),
+			},
+			"ip_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      4,
+				ValidateFunc: validation.IntInSlice([]int{4, 6}),
+			},
+			"addresses": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func vpcAddressGroupURL(client *golangsdk.ServiceClient, id ...string) string {
+	return client.ServiceURL(append([]string{client.ProjectID, "vpc", "address-groups"}, id...)...)
+}
+
+func getVpcAddressGroup(vpcClient *golangsdk.ServiceClient, id string) (*vpcAddressGroup, error) {
+	client := vpcV3Client(vpcClient)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(vpcAddressGroupURL(client, id), &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if rst.Err != nil {
+		return nil, rst.Err
+	}
+
+	var response struct {
+		AddressGroup vpcAddressGroup `json:"address_group"`
+	}
+	err := rst.ExtractInto(&response)
+	return &response.AddressGroup, err
+}
+
+func resourceVpcAddressGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+	client := vpcV3Client(vpcClient)
+
+	description := d.Get("description").(string)
+	createOpts := vpcAddressGroup{
+		Name:                d.Get("name").(string),
+		Description:         &description,
+		IPVersion:           d.Get("ip_version").(int),
+		IPSet:               utils.ExpandToStringList(d.Get("addresses").(*schema.Set).List()),
+		EnterpriseProjectID: GetEnterpriseProjectID(d, config),
+	}
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(vpcAddressGroupURL(client), map[string]interface{}{"address_group": createOpts},
+		&rst.Body, &golangsdk.RequestOpts{
+			OkCodes: []int{200, 201},
+		})
+	if rst.Err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC address group: %s", rst.Err)
+	}
+
+	var response struct {
+		AddressGroup vpcAddressGroup `json:"address_group"`
+	}
+	if err := rst.ExtractInto(&response); err != nil {
+		return fmt.Errorf("Error extracting SberCloud VPC address group: %s", err)
+	}
+	d.SetId(response.AddressGroup.ID)
+
+	return resourceVpcAddressGroupRead(d, meta)
+}
+
+func resourceVpcAddressGroupRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	vpcClient, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	group, err := getVpcAddressGroup(vpcClient, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud VPC address group")
+	}
+	log.Printf("[DEBUG] Retrieved SberCloud VPC address group %s: %#v", d.Id(), group)
+
+	d.Set("region", region)
+	d.Set("name", group.Name)
+	if group.Description != nil {
+		d.Set("description", *group.Description)
+	}
+	d.Set("ip_version", group.IPVersion)
+	d.Set("addresses", group.IPSet)
+	d.Set("enterprise_project_id", group.EnterpriseProjectID)
+
+	return nil
+}
+
+func resourceVpcAddressGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+	client := vpcV3Client(vpcClient)
+
+	updateOpts := vpcAddressGroup{
+		Name: d.Get("name").(string),
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+	if d.HasChange("addresses") {
+		updateOpts.IPSet = utils.ExpandToStringList(d.Get("addresses").(*schema.Set).List())
+	}
+	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+
+	_, err = client.Put(vpcAddressGroupURL(client, d.Id()), map[string]interface{}{"address_group": updateOpts}, nil,
+		&golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	if err != nil {
+		return fmt.Errorf("Error updating SberCloud VPC address group %s: %s", d.Id(), err)
+	}
+
+	return resourceVpcAddressGroupRead(d, meta)
+}
+
+func resourceVpcAddressGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+	client := vpcV3Client(vpcClient)
+
+	_, err = client.Delete(vpcAddressGroupURL(client, d.Id()), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud VPC address group")
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,105 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpcAddressGroup_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_address_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcAddressGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcAddressGroup_basic(rName, `"192.168.10.10", "192.168.1.1-192.168.1.50"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcAddressGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "ip_version", "4"),
+					resource.TestCheckResourceAttr(resourceName, "addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccVpcAddressGroup_basic(rName, `"192.168.10.10", "192.168.5.0/24", "192.168.6.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcAddressGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "addresses.#", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpcAddressGroupDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	vpcClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpc_address_group" {
+			continue
+		}
+
+		if _, err := getVpcAddressGroup(vpcClient, rs.Primary.ID); err == nil {
+			return fmt.Errorf("VPC address group still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcAddressGroupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		vpcClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+		}
+
+		found, err := getVpcAddressGroup(vpcClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC address group not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccVpcAddressGroup_basic(rName, addresses string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_address_group" "test" {
+  name        = "%s"
+  description = "created by acc test"
+  addresses   = [%s]
+}
+`, rName, addresses)
+}
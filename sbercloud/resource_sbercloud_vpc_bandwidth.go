@@ -0,0 +1,252 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	bandwidthsv1 "github.com/huaweicloud/golangsdk/openstack/networking/v1/bandwidths"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/bandwidths"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// bandwidthUpdateOpts extends the bandwidth update of golangsdk with the charge mode.
+type bandwidthUpdateOpts struct {
+	Name       string `json:"name,omitempty"`
+	Size       int    `json:"size,omitempty"`
+	ChargeMode string `json:"charge_mode,omitempty"`
+}
+
+func (opts bandwidthUpdateOpts) ToBWUpdateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "bandwidth")
+}
+
+func ResourceVpcBandWidthV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcBandWidthV2Create,
+		Read:   resourceVpcBandWidthV2Read,
+		Update: resourceVpcBandWidthV2Update,
+		Delete: resourceVpcBandWidthV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(5, 2000),
+			},
+			"charge_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"bandwidth", "95peak_plus",
+				}, false),
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"share_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpcBandWidthV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	networkingClient, err := config.NetworkingV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+	networkingV1Client, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	size := d.Get("size").(int)
+	createOpts := bandwidths.CreateOpts{
+		Name: d.Get("name").(string),
+		Size: &size,
+	}
+
+	epsID := GetEnterpriseProjectID(d, config)
+	if epsID != "" {
+		createOpts.EnterpriseProjectId = epsID
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	b, err := bandwidths.Create(networkingClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating Bandwidth: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for Bandwidth (%s) to become available.", b.ID)
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"NORMAL"},
+		Pending:    []string{"CREATING"},
+		Refresh:    waitForBandwidth(networkingV1Client, b.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for Bandwidth (%s) to become ACTIVE for creation: %s",
+			b.ID, err)
+	}
+	d.SetId(b.ID)
+
+	// the charge mode is not supported on creation, it is changed afterwards
+	if chargeMode := d.Get("charge_mode").(string); chargeMode != "" && chargeMode != b.ChargeMode {
+		updateOpts := bandwidthUpdateOpts{ChargeMode: chargeMode}
+		log.Printf("[DEBUG] Updating Bandwidth %s with options: %#v", b.ID, updateOpts)
+		_, err = bandwidthsv1.Update(networkingV1Client, b.ID, updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating the charge mode of Bandwidth (%s): %s", b.ID, err)
+		}
+	}
+
+	return resourceVpcBandWidthV2Read(d, meta)
+}
+
+func resourceVpcBandWidthV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	var updateOpts bandwidthUpdateOpts
+	if d.HasChange("name") {
+		updateOpts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("size") {
+		updateOpts.Size = d.Get("size").(int)
+	}
+	if d.HasChange("charge_mode") {
+		updateOpts.ChargeMode = d.Get("charge_mode").(string)
+	}
+
+	if updateOpts != (bandwidthUpdateOpts{}) {
+		log.Printf("[DEBUG] Updating Bandwidth %s with options: %#v", d.Id(), updateOpts)
+		_, err := bandwidthsv1.Update(networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud BandWidth (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceVpcBandWidthV2Read(d, meta)
+}
+
+func resourceVpcBandWidthV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	b, err := bandwidthsv1.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Bandwidth")
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", b.Name)
+	d.Set("size", b.Size)
+	d.Set("enterprise_project_id", b.EnterpriseProjectID)
+
+	d.Set("share_type", b.ShareType)
+	d.Set("bandwidth_type", b.BandwidthType)
+	d.Set("charge_mode", b.ChargeMode)
+	d.Set("status", b.Status)
+	return nil
+}
+
+func resourceVpcBandWidthV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	networkingClient, err := config.NetworkingV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+	networkingV1Client, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	err = bandwidths.Delete(networkingClient, d.Id()).ExtractErr()
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud Bandwidth")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"NORMAL"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForBandwidth(networkingV1Client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting Bandwidth: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForBandwidth(networkingClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		b, err := bandwidthsv1.Get(networkingClient, id).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return b, "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] SberCloud Bandwidth (%s) current status: %s", b.ID, b.Status)
+		return b, b.Status, nil
+	}
+}
@@ -0,0 +1,227 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	bandwidthsv1 "github.com/huaweicloud/golangsdk/openstack/networking/v1/bandwidths"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/bandwidths"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// ResourceVpcBandWidthAssociate adds existing EIPs to a shared bandwidth. The
+// EIPs removed from the shared bandwidth get a dedicated bandwidth again, with
+// the bandwidth_size and bandwidth_charge_mode.
+func ResourceVpcBandWidthAssociate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcBandWidthAssociateCreate,
+		Read:   resourceVpcBandWidthAssociateRead,
+		Update: resourceVpcBandWidthAssociateUpdate,
+		Delete: resourceVpcBandWidthAssociateDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVpcBandWidthAssociateImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bandwidth_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"eip_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"bandwidth_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 300),
+			},
+			"bandwidth_charge_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "bandwidth",
+				ValidateFunc: validation.StringInSlice([]string{
+					"bandwidth", "traffic",
+				}, false),
+			},
+			"public_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVpcBandWidthAssociateCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	bandwidthID := d.Get("bandwidth_id").(string)
+	eipIDs := d.Get("eip_ids").(*schema.Set)
+	if err := insertEIPsToBandwidth(networkingClient, bandwidthID, eipIDs); err != nil {
+		return err
+	}
+
+	d.SetId(bandwidthID)
+	return resourceVpcBandWidthAssociateRead(d, meta)
+}
+
+func resourceVpcBandWidthAssociateRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	b, err := bandwidthsv1.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Bandwidth")
+	}
+
+	// only the EIPs added by this resource are tracked, the shared bandwidth
+	// may hold other EIPs, e.g. created with the bandwidth id
+	managed := d.Get("eip_ids").(*schema.Set)
+	eipIDs := make([]string, 0, len(b.PublicipInfo))
+	addresses := make([]string, 0, len(b.PublicipInfo))
+	for _, info := range b.PublicipInfo {
+		if !managed.Contains(info.PublicipId) {
+			continue
+		}
+		eipIDs = append(eipIDs, info.PublicipId)
+		addresses = append(addresses, info.PublicipAddress)
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("bandwidth_id", b.ID)
+	d.Set("eip_ids", eipIDs)
+	d.Set("public_ips", addresses)
+
+	return nil
+}
+
+func resourceVpcBandWidthAssociateUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	if d.HasChange("eip_ids") {
+		o, n := d.GetChange("eip_ids")
+		oldEIPs := o.(*schema.Set)
+		newEIPs := n.(*schema.Set)
+
+		if err := removeEIPsFromBandwidth(d, networkingClient, oldEIPs.Difference(newEIPs)); err != nil {
+			return err
+		}
+		if err := insertEIPsToBandwidth(networkingClient, d.Id(), newEIPs.Difference(oldEIPs)); err != nil {
+			return err
+		}
+	}
+
+	return resourceVpcBandWidthAssociateRead(d, meta)
+}
+
+func resourceVpcBandWidthAssociateDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	if err := removeEIPsFromBandwidth(d, networkingClient, d.Get("eip_ids").(*schema.Set)); err != nil {
+		return CheckDeleted(d, err, "Error removing the EIPs from SberCloud Bandwidth")
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceVpcBandWidthAssociateImport imports all EIPs of the shared bandwidth.
+func resourceVpcBandWidthAssociateImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	b, err := bandwidthsv1.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving SberCloud Bandwidth (%s): %s", d.Id(), err)
+	}
+
+	eipIDs := make([]string, len(b.PublicipInfo))
+	for i, info := range b.PublicipInfo {
+		eipIDs[i] = info.PublicipId
+	}
+
+	d.Set("bandwidth_id", d.Id())
+	d.Set("eip_ids", eipIDs)
+	d.Set("bandwidth_size", 5)
+	d.Set("bandwidth_charge_mode", "bandwidth")
+	return []*schema.ResourceData{d}, nil
+}
+
+func insertEIPsToBandwidth(client *golangsdk.ServiceClient, bandwidthID string, eipIDs *schema.Set) error {
+	if eipIDs.Len() == 0 {
+		return nil
+	}
+
+	insertOpts := bandwidths.BandWidthInsertOpts{
+		PublicipInfo: expandBandwidthPublicIPInfo(eipIDs),
+	}
+	log.Printf("[DEBUG] Inserting the EIPs to Bandwidth %s: %#v", bandwidthID, insertOpts)
+	_, err := bandwidths.Insert(client, bandwidthID, insertOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error inserting the EIPs to SberCloud Bandwidth (%s): %s", bandwidthID, err)
+	}
+	return nil
+}
+
+func removeEIPsFromBandwidth(d *schema.ResourceData, client *golangsdk.ServiceClient, eipIDs *schema.Set) error {
+	if eipIDs.Len() == 0 {
+		return nil
+	}
+
+	size := d.Get("bandwidth_size").(int)
+	removeOpts := bandwidths.BandWidthRemoveOpts{
+		ChargeMode:   d.Get("bandwidth_charge_mode").(string),
+		Size:         &size,
+		PublicipInfo: expandBandwidthPublicIPInfo(eipIDs),
+	}
+	log.Printf("[DEBUG] Removing the EIPs from Bandwidth %s: %#v", d.Id(), removeOpts)
+	err := bandwidths.Remove(client, d.Id(), removeOpts).ExtractErr()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return err
+		}
+		return fmt.Errorf("Error removing the EIPs from SberCloud Bandwidth (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+func expandBandwidthPublicIPInfo(eipIDs *schema.Set) []bandwidths.PublicIpInfoID {
+	infos := make([]bandwidths.PublicIpInfoID, eipIDs.Len())
+	for i, id := range eipIDs.List() {
+		infos[i] = bandwidths.PublicIpInfoID{PublicIPID: id.(string)}
+	}
+	return infos
+}
@@ -0,0 +1,127 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpcBandWidthAssociate_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_bandwidth_associate.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthAssociateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthAssociate_basic(rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "bandwidth_id",
+						"sbercloud_vpc_bandwidth.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "eip_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "public_ips.#", "2"),
+					testAccCheckVpcEIPBandwidthShareType("sbercloud_vpc_eip.test.0", "WHOLE"),
+					testAccCheckVpcEIPBandwidthShareType("sbercloud_vpc_eip.test.1", "WHOLE"),
+				),
+			},
+			{
+				Config: testAccVpcBandWidthAssociate_basic(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "eip_ids.#", "1"),
+					testAccCheckVpcEIPBandwidthShareType("sbercloud_vpc_eip.test.0", "WHOLE"),
+					testAccCheckVpcEIPBandwidthShareType("sbercloud_vpc_eip.test.1", "PER"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bandwidth_size", "bandwidth_charge_mode"},
+			},
+		},
+	})
+}
+
+func testAccCheckVpcBandWidthAssociateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating sbercloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpc_eip" {
+			continue
+		}
+
+		eip, err := eips.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil && eip.BandwidthShareType == "WHOLE" {
+			return fmt.Errorf("EIP %s is still in the shared bandwidth", eip.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcEIPBandwidthShareType(n, shareType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating networking client: %s", err)
+		}
+
+		eip, err := eips.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+		if eip.BandwidthShareType != shareType {
+			return fmt.Errorf("Expected the %s bandwidth of EIP %s, got %s", shareType, eip.ID, eip.BandwidthShareType)
+		}
+
+		return nil
+	}
+}
+
+func testAccVpcBandWidthAssociate_basic(rName string, count int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_bandwidth" "test" {
+  name = "%[1]s"
+  size = 10
+}
+
+resource "sbercloud_vpc_eip" "test" {
+  count = 2
+
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name        = "%[1]s-${count.index}"
+    size        = 5
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+}
+
+resource "sbercloud_vpc_bandwidth_associate" "test" {
+  bandwidth_id          = sbercloud_vpc_bandwidth.test.id
+  eip_ids               = slice(sbercloud_vpc_eip.test[*].id, 0, %[2]d)
+  bandwidth_size        = 5
+  bandwidth_charge_mode = "traffic"
+}
+`, rName, count)
+}
@@ -69,6 +69,37 @@ func TestAccVpcBandWidthV2_WithEpsId(t *testing.T) {
 	})
 }
 
+func TestAccVpcBandWidthV2_chargeMode(t *testing.T) {
+	var bandwidth bandwidths.BandWidth
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_bandwidth.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthV2_chargeMode(rName, 300, "95peak_plus"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcBandWidthV2Exists(resourceName, &bandwidth),
+					resource.TestCheckResourceAttr(resourceName, "size", "300"),
+					resource.TestCheckResourceAttr(resourceName, "charge_mode", "95peak_plus"),
+				),
+			},
+			{
+				Config: testAccVpcBandWidthV2_chargeMode(rName, 10, "bandwidth"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcBandWidthV2Exists(resourceName, &bandwidth),
+					resource.TestCheckResourceAttr(resourceName, "size", "10"),
+					resource.TestCheckResourceAttr(resourceName, "charge_mode", "bandwidth"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcBandWidthV2Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	networkingClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
@@ -140,3 +171,13 @@ resource "sbercloud_vpc_bandwidth" "test" {
 }
 `, rName, size, SBC_ENTERPRISE_PROJECT_ID_TEST)
 }
+
+func testAccVpcBandWidthV2_chargeMode(rName string, size int, chargeMode string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_bandwidth" "test" {
+  name        = "%s"
+  size        = %d
+  charge_mode = "%s"
+}
+`, rName, size, chargeMode)
+}
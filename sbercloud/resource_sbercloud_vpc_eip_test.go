@@ -33,6 +33,43 @@ func TestAccVpcV1EIP_basic(t *testing.T) {
 	})
 }
 
+func TestAccVpcV1EIP_update(t *testing.T) {
+	var eip, updated eips.PublicIp
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_eip.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcV1EIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcV1EIP_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1EIPExists(resourceName, &eip),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth.0.size", "8"),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth.0.charge_mode", "traffic"),
+				),
+			},
+			{
+				Config: testAccVpcV1EIP_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1EIPExists(resourceName, &updated),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth.0.size", "10"),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth.0.charge_mode", "bandwidth"),
+					func(*terraform.State) error {
+						if updated.ID != eip.ID || updated.PublicAddress != eip.PublicAddress {
+							return fmt.Errorf("EIP is recreated on the bandwidth update")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccVpcV1EIP_share(t *testing.T) {
 	var eip eips.PublicIp
 
@@ -123,6 +160,22 @@ resource "sbercloud_vpc_eip" "test" {
 `, rName)
 }
 
+func testAccVpcV1EIP_update(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc_eip" "test" {
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name        = "%s"
+    size        = 10
+    share_type  = "PER"
+    charge_mode = "bandwidth"
+  }
+}
+`, rName)
+}
+
 func testAccVpcV1EIP_share(rName string) string {
 	return fmt.Sprintf(`
 resource "sbercloud_vpc_bandwidth" "test" {
@@ -0,0 +1,255 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// vpcFlowLog is a flow log of the VPC v1 API, which records the traffic of a
+// port, a subnet or a VPC to an LTS log topic.
+type vpcFlowLog struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	TrafficType  string `json:"traffic_type,omitempty"`
+	LogGroupID   string `json:"log_group_id,omitempty"`
+	LogTopicID   string `json:"log_topic_id,omitempty"`
+	AdminState   *bool  `json:"admin_state,omitempty"`
+	Status       string `json:"status,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+}
+
+var vpcFlowLogNameRegexp = regexp.MustCompile(`^[\p{Han}\w.-]*$`)
+
+func ResourceVpcFlowLogV1() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcFlowLogV1Create,
+		Read:   resourceVpcFlowLogV1Read,
+		Update: resourceVpcFlowLogV1Update,
+		Delete: resourceVpcFlowLogV1Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 64),
+					validation.StringMatch(vpcFlowLogNameRegexp,
+						"only letters, digits, underscores (_), hyphens (-) and periods (.) are allowed"),
+				),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 255),
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"port", "network", "vpc",
+				}, false),
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"traffic_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "all",
+				ValidateFunc: validation.StringInSlice([]string{
+					"all", "accept", "reject",
+				}, false),
+			},
+			"log_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"log_topic_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func vpcFlowLogURL(client *golangsdk.ServiceClient, id ...string) string {
+	return client.ServiceURL(append([]string{client.ProjectID, "fl", "flow_logs"}, id...)...)
+}
+
+func getVpcFlowLog(client *golangsdk.ServiceClient, id string) (*vpcFlowLog, error) {
+	var rst golangsdk.Result
+	_, rst.Err = client.Get(vpcFlowLogURL(client, id), &rst.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if rst.Err != nil {
+		return nil, rst.Err
+	}
+
+	var response struct {
+		FlowLog vpcFlowLog `json:"flow_log"`
+	}
+	err := rst.ExtractInto(&response)
+	return &response.FlowLog, err
+}
+
+func updateVpcFlowLog(client *golangsdk.ServiceClient, id string, opts vpcFlowLog) error {
+	body := map[string]interface{}{"flow_log": opts}
+	_, err := client.Put(vpcFlowLogURL(client, id), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+func resourceVpcFlowLogV1Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	createOpts := vpcFlowLog{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		ResourceType: d.Get("resource_type").(string),
+		ResourceID:   d.Get("resource_id").(string),
+		TrafficType:  d.Get("traffic_type").(string),
+		LogGroupID:   d.Get("log_group_id").(string),
+		LogTopicID:   d.Get("log_topic_id").(string),
+	}
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	var rst golangsdk.Result
+	_, rst.Err = client.Post(vpcFlowLogURL(client), map[string]interface{}{"flow_log": createOpts}, &rst.Body,
+		&golangsdk.RequestOpts{
+			OkCodes: []int{200, 201},
+		})
+	if rst.Err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC flow log: %s", rst.Err)
+	}
+
+	var response struct {
+		FlowLog vpcFlowLog `json:"flow_log"`
+	}
+	if err := rst.ExtractInto(&response); err != nil {
+		return fmt.Errorf("Error extracting SberCloud VPC flow log: %s", err)
+	}
+	d.SetId(response.FlowLog.ID)
+
+	// a flow log is enabled on creation
+	if !d.Get("enabled").(bool) {
+		enabled := false
+		disableOpts := vpcFlowLog{
+			Name:        createOpts.Name,
+			Description: createOpts.Description,
+			AdminState:  &enabled,
+		}
+		if err := updateVpcFlowLog(client, d.Id(), disableOpts); err != nil {
+			return fmt.Errorf("Error disabling SberCloud VPC flow log %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceVpcFlowLogV1Read(d, meta)
+}
+
+func resourceVpcFlowLogV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.NetworkingV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	flowLog, err := getVpcFlowLog(client, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud VPC flow log")
+	}
+	log.Printf("[DEBUG] Retrieved SberCloud VPC flow log %s: %#v", d.Id(), flowLog)
+
+	d.Set("region", region)
+	d.Set("name", flowLog.Name)
+	d.Set("description", flowLog.Description)
+	d.Set("resource_type", flowLog.ResourceType)
+	d.Set("resource_id", flowLog.ResourceID)
+	d.Set("traffic_type", flowLog.TrafficType)
+	d.Set("log_group_id", flowLog.LogGroupID)
+	d.Set("log_topic_id", flowLog.LogTopicID)
+	d.Set("enabled", flowLog.AdminState != nil && *flowLog.AdminState)
+	d.Set("status", flowLog.Status)
+
+	return nil
+}
+
+func resourceVpcFlowLogV1Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	enabled := d.Get("enabled").(bool)
+	updateOpts := vpcFlowLog{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		AdminState:  &enabled,
+	}
+	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+
+	if err := updateVpcFlowLog(client, d.Id(), updateOpts); err != nil {
+		return fmt.Errorf("Error updating SberCloud VPC flow log %s: %s", d.Id(), err)
+	}
+
+	return resourceVpcFlowLogV1Read(d, meta)
+}
+
+func resourceVpcFlowLogV1Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	client, err := config.NetworkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	_, err = client.Delete(vpcFlowLogURL(client, d.Id()), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud VPC flow log")
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,183 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpcFlowLog_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_flow_log.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLTS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLog_basic(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "resource_type", "vpc"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_type", "reject"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_id", "sbercloud_vpc.test", "id"),
+				),
+			},
+			{
+				Config: testAccVpcFlowLog_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "description", "flow log of the VPC"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVpcFlowLog_network(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_flow_log.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLTS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLog_network(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "resource_type", "network"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_type", "all"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_id", "sbercloud_vpc_subnet.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcFlowLogDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.NetworkingV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpc_flow_log" {
+			continue
+		}
+
+		if _, err := getVpcFlowLog(client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("VPC flow log still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcFlowLogExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.NetworkingV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud VPC client: %s", err)
+		}
+
+		found, err := getVpcFlowLog(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC flow log not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccVpcFlowLog_basic(rName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_flow_log" "test" {
+  name          = "%[1]s"
+  resource_type = "vpc"
+  resource_id   = sbercloud_vpc.test.id
+  traffic_type  = "reject"
+  log_group_id  = "%[2]s"
+  log_topic_id  = "%[3]s"
+  enabled       = %[4]t
+}
+`, rName, SBC_LTS_GROUP_ID, SBC_LTS_TOPIC_ID, enabled)
+}
+
+func testAccVpcFlowLog_update(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_flow_log" "test" {
+  name          = "%[1]s-update"
+  description   = "flow log of the VPC"
+  resource_type = "vpc"
+  resource_id   = sbercloud_vpc.test.id
+  traffic_type  = "reject"
+  log_group_id  = "%[2]s"
+  log_topic_id  = "%[3]s"
+}
+`, rName, SBC_LTS_GROUP_ID, SBC_LTS_TOPIC_ID)
+}
+
+func testAccVpcFlowLog_network(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_subnet" "test" {
+  name       = "%[1]s"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = sbercloud_vpc.test.id
+}
+
+resource "sbercloud_vpc_flow_log" "test" {
+  name          = "%[1]s"
+  resource_type = "network"
+  resource_id   = sbercloud_vpc_subnet.test.id
+  log_group_id  = "%[2]s"
+  log_topic_id  = "%[3]s"
+}
+`, rName, SBC_LTS_GROUP_ID, SBC_LTS_TOPIC_ID)
+}
@@ -0,0 +1,446 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/peerings"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/routes"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func ResourceVpcPeeringConnectionV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVPCPeeringV2Create,
+		Read:   resourceVPCPeeringV2Read,
+		Update: resourceVPCPeeringV2Update,
+		Delete: resourceVPCPeeringV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVPCPeeringV2Import,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{ //request and response parameters
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateString64WithChinese,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"peer_vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"peer_tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"requester_routes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"accepter_routes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVPCPeeringV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc Peering Connection Client: %s", err)
+	}
+
+	// the routes in the peer VPC of another tenant are created by the accepter
+	peerTenantID := d.Get("peer_tenant_id").(string)
+	if _, ok := d.GetOk("accepter_routes"); ok && peerTenantID != "" && peerTenantID != peeringClient.ProjectID {
+		return fmt.Errorf("accepter_routes can only be set for a peer VPC in the same project, " +
+			"use accepter_routes of sbercloud_vpc_peering_connection_accepter instead")
+	}
+
+	requestvpcinfo := peerings.VpcInfo{
+		VpcId: d.Get("vpc_id").(string),
+	}
+
+	acceptvpcinfo := peerings.VpcInfo{
+		VpcId:    d.Get("peer_vpc_id").(string),
+		TenantId: d.Get("peer_tenant_id").(string),
+	}
+
+	createOpts := peerings.CreateOpts{
+		Name:           d.Get("name").(string),
+		RequestVpcInfo: requestvpcinfo,
+		AcceptVpcInfo:  acceptvpcinfo,
+	}
+
+	n, err := peerings.Create(peeringClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc Peering Connection: %s", err)
+	}
+
+	d.SetId(n.ID)
+	log.Printf("[INFO] Vpc Peering Connection ID: %s", n.ID)
+
+	// the connection with a VPC of another tenant stays in PENDING_ACCEPTANCE
+	// until it is accepted by the sbercloud_vpc_peering_connection_accepter
+	log.Printf("[INFO] Waiting for SberCloud Vpc Peering Connection(%s) to become available", n.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING"},
+		Target:     []string{"PENDING_ACCEPTANCE", "ACTIVE"},
+		Refresh:    waitForVpcPeeringActive(peeringClient, n.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	result, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for SberCloud Vpc Peering Connection(%s) to become available: %s", n.ID, err)
+	}
+
+	// the routes can only be added once the connection is accepted, the
+	// next apply creates them after the acceptance
+	if result.(*peerings.Peering).Status == "ACTIVE" {
+		routesToCreate := map[string]string{
+			"requester_routes": d.Get("vpc_id").(string),
+			"accepter_routes":  d.Get("peer_vpc_id").(string),
+		}
+		for key, vpcID := range routesToCreate {
+			destinations := utils.ExpandToStringList(d.Get(key).(*schema.Set).List())
+			if err := createVpcPeeringRoutes(peeringClient, vpcID, n.ID, destinations); err != nil {
+				return err
+			}
+		}
+	} else if v, ok := d.GetOk("requester_routes"); ok {
+		log.Printf("[WARN] The routes %v will be created after the Vpc Peering Connection(%s) is accepted",
+			v.(*schema.Set).List(), n.ID)
+	}
+
+	return resourceVPCPeeringV2Read(d, meta)
+}
+
+func resourceVPCPeeringV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc Peering Connection Client: %s", err)
+	}
+
+	n, err := peerings.Get(peeringClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Vpc Peering Connection")
+	}
+
+	d.Set("name", n.Name)
+	d.Set("status", n.Status)
+	d.Set("vpc_id", n.RequestVpcInfo.VpcId)
+	d.Set("peer_vpc_id", n.AcceptVpcInfo.VpcId)
+	d.Set("peer_tenant_id", n.AcceptVpcInfo.TenantId)
+	d.Set("region", GetRegion(d, config))
+
+	requesterRoutes, err := listVpcPeeringRoutes(peeringClient, n.RequestVpcInfo.VpcId, n.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("requester_routes", vpcPeeringRouteDestinations(d, "requester_routes", requesterRoutes))
+
+	// the routes in the peer VPC of another tenant can not be listed here
+	if n.AcceptVpcInfo.TenantId == "" || n.AcceptVpcInfo.TenantId == n.RequestVpcInfo.TenantId {
+		accepterRoutes, err := listVpcPeeringRoutes(peeringClient, n.AcceptVpcInfo.VpcId, n.ID)
+		if err != nil {
+			return err
+		}
+		d.Set("accepter_routes", vpcPeeringRouteDestinations(d, "accepter_routes", accepterRoutes))
+	}
+
+	return nil
+}
+
+// resourceVPCPeeringV2Import manages all the routes to the connection, as
+// the routes of an imported connection are not known from the state.
+func resourceVPCPeeringV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating SberCloud Vpc Peering Connection Client: %s", err)
+	}
+
+	n, err := peerings.Get(peeringClient, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving SberCloud Vpc Peering Connection %s: %s", d.Id(), err)
+	}
+
+	if err := importVpcPeeringRoutes(d, peeringClient, "requester_routes", n.RequestVpcInfo.VpcId); err != nil {
+		return nil, err
+	}
+	if n.AcceptVpcInfo.TenantId == "" || n.AcceptVpcInfo.TenantId == n.RequestVpcInfo.TenantId {
+		if err := importVpcPeeringRoutes(d, peeringClient, "accepter_routes", n.AcceptVpcInfo.VpcId); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVPCPeeringV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc Peering Connection Client: %s", err)
+	}
+
+	if d.HasChange("name") {
+		var updateOpts peerings.UpdateOpts
+
+		updateOpts.Name = d.Get("name").(string)
+
+		_, err = peerings.Update(peeringClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud Vpc Peering Connection: %s", err)
+		}
+	}
+
+	if d.HasChanges("requester_routes", "accepter_routes") {
+		if status := d.Get("status").(string); status != "ACTIVE" {
+			return fmt.Errorf("Error updating the routes of SberCloud Vpc Peering Connection(%s): "+
+				"the routes can only be added to an ACTIVE connection, got %s", d.Id(), status)
+		}
+		if err := updateVpcPeeringRoutes(d, peeringClient, "requester_routes", d.Get("vpc_id").(string)); err != nil {
+			return err
+		}
+		if err := updateVpcPeeringRoutes(d, peeringClient, "accepter_routes", d.Get("peer_vpc_id").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceVPCPeeringV2Read(d, meta)
+}
+
+func resourceVPCPeeringV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Vpc Peering Connection Client: %s", err)
+	}
+
+	// the connection can not be deleted while the routes refer to it
+	routesToDelete := map[string]string{
+		"requester_routes": d.Get("vpc_id").(string),
+		"accepter_routes":  d.Get("peer_vpc_id").(string),
+	}
+	for key, vpcID := range routesToDelete {
+		destinations := utils.ExpandToStringList(d.Get(key).(*schema.Set).List())
+		if err := deleteVpcPeeringRoutes(peeringClient, vpcID, d.Id(), destinations); err != nil {
+			return err
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForVpcPeeringDelete(peeringClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting SberCloud Vpc Peering Connection: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForVpcPeeringActive(peeringClient *golangsdk.ServiceClient, peeringId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := peerings.Get(peeringClient, peeringId).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch n.Status {
+		case "PENDING_ACCEPTANCE", "ACTIVE":
+			return n, n.Status, nil
+		case "REJECTED", "EXPIRED":
+			return n, n.Status, fmt.Errorf("the VPC peering connection is %s", n.Status)
+		}
+
+		return n, "CREATING", nil
+	}
+}
+
+func waitForVpcPeeringDelete(peeringClient *golangsdk.ServiceClient, peeringId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		r, err := peerings.Get(peeringClient, peeringId).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[INFO] Successfully deleted SberCloud vpc peering connection %s", peeringId)
+				return r, "DELETED", nil
+			}
+			return r, "ACTIVE", err
+		}
+
+		err = peerings.Delete(peeringClient, peeringId).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[INFO] Successfully deleted SberCloud vpc peering connection %s", peeringId)
+				return r, "DELETED", nil
+			}
+			if errCode, ok := err.(golangsdk.ErrUnexpectedResponseCode); ok {
+				if errCode.Actual == 409 {
+					return r, "ACTIVE", nil
+				}
+			}
+			return r, "ACTIVE", err
+		}
+
+		return r, "ACTIVE", nil
+	}
+}
+
+// listVpcPeeringRoutes returns the routes of the VPC whose next hop is the
+// peering connection, by destination.
+func listVpcPeeringRoutes(client *golangsdk.ServiceClient, vpcID, peeringID string) (map[string]routes.Route, error) {
+	listOpts := routes.ListOpts{
+		Type:   "peering",
+		VPC_ID: vpcID,
+	}
+	pages, err := routes.List(client, listOpts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving SberCloud routes of VPC %s: %s", vpcID, err)
+	}
+	allRoutes, err := routes.ExtractRoutes(pages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting SberCloud routes of VPC %s: %s", vpcID, err)
+	}
+
+	result := make(map[string]routes.Route)
+	for _, route := range allRoutes {
+		if route.NextHop == peeringID {
+			result[route.Destination] = route
+		}
+	}
+	return result, nil
+}
+
+// vpcPeeringRouteDestinations returns the destinations of the key which are
+// still routed to the connection. The other routes to the connection, e.g.
+// created by sbercloud_vpc_route, are not managed by the key.
+func vpcPeeringRouteDestinations(d *schema.ResourceData, key string, peeringRoutes map[string]routes.Route) []string {
+	destinations := make([]string, 0)
+	for _, destination := range utils.ExpandToStringList(d.Get(key).(*schema.Set).List()) {
+		if _, ok := peeringRoutes[destination]; ok {
+			destinations = append(destinations, destination)
+		}
+	}
+	return destinations
+}
+
+// importVpcPeeringRoutes sets the key to the destinations of all the routes
+// of the VPC whose next hop is the connection.
+func importVpcPeeringRoutes(d *schema.ResourceData, client *golangsdk.ServiceClient, key, vpcID string) error {
+	peeringRoutes, err := listVpcPeeringRoutes(client, vpcID, d.Id())
+	if err != nil {
+		return err
+	}
+
+	destinations := make([]string, 0, len(peeringRoutes))
+	for destination := range peeringRoutes {
+		destinations = append(destinations, destination)
+	}
+	return d.Set(key, destinations)
+}
+
+func createVpcPeeringRoutes(client *golangsdk.ServiceClient, vpcID, peeringID string, destinations []string) error {
+	for _, destination := range destinations {
+		createOpts := routes.CreateOpts{
+			Type:        "peering",
+			NextHop:     peeringID,
+			Destination: destination,
+			VPC_ID:      vpcID,
+		}
+		route, err := routes.Create(client, createOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud route to %s in VPC %s: %s", destination, vpcID, err)
+		}
+		log.Printf("[DEBUG] Created route %s to %s in VPC %s", route.RouteID, destination, vpcID)
+	}
+	return nil
+}
+
+func deleteVpcPeeringRoutes(client *golangsdk.ServiceClient, vpcID, peeringID string, destinations []string) error {
+	if len(destinations) == 0 {
+		return nil
+	}
+
+	peeringRoutes, err := listVpcPeeringRoutes(client, vpcID, peeringID)
+	if err != nil {
+		return err
+	}
+	for _, destination := range destinations {
+		route, ok := peeringRoutes[destination]
+		if !ok {
+			continue
+		}
+		err := routes.Delete(client, route.RouteID).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error deleting SberCloud route to %s in VPC %s: %s", destination, vpcID, err)
+		}
+		log.Printf("[DEBUG] Deleted route %s to %s in VPC %s", route.RouteID, destination, vpcID)
+	}
+	return nil
+}
+
+// updateVpcPeeringRoutes deletes the routes removed from the key and creates
+// the added ones in the VPC.
+func updateVpcPeeringRoutes(d *schema.ResourceData, client *golangsdk.ServiceClient, key, vpcID string) error {
+	if !d.HasChange(key) {
+		return nil
+	}
+
+	o, n := d.GetChange(key)
+	oldRoutes, newRoutes := o.(*schema.Set), n.(*schema.Set)
+	removed := utils.ExpandToStringList(oldRoutes.Difference(newRoutes).List())
+	if err := deleteVpcPeeringRoutes(client, vpcID, d.Id(), removed); err != nil {
+		return err
+	}
+	added := utils.ExpandToStringList(newRoutes.Difference(oldRoutes).List())
+	return createVpcPeeringRoutes(client, vpcID, d.Id(), added)
+}
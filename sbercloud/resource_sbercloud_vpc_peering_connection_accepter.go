@@ -0,0 +1,251 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/peerings"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceVpcPeeringConnectionAccepterV2 accepts or rejects a VPC peering
+// connection requested by another tenant, so it is usually managed through a
+// provider alias with the credentials of the accepter tenant.
+func ResourceVpcPeeringConnectionAccepterV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVPCPeeringAccepterV2Create,
+		Read:   resourceVpcPeeringAccepterRead,
+		Update: resourceVPCPeeringAccepterUpdate,
+		Delete: resourceVPCPeeringAccepterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVPCPeeringAccepterImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_peering_connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"accepter_routes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVPCPeeringAccepterV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Peering client: %s", err)
+	}
+
+	id := d.Get("vpc_peering_connection_id").(string)
+	n, err := peerings.Get(peeringClient, id).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving SberCloud Vpc Peering Connection: %s", err)
+	}
+
+	expectedStatus := "REJECTED"
+	if d.Get("accept").(bool) {
+		expectedStatus = "ACTIVE"
+	} else if _, ok := d.GetOk("accepter_routes"); ok {
+		return fmt.Errorf("accepter_routes can only be set when the connection is accepted")
+	}
+
+	// the connection may be already accepted, e.g. when it is requested within the same tenant
+	if n.Status != expectedStatus {
+		if n.Status != "PENDING_ACCEPTANCE" {
+			return fmt.Errorf("VPC peering action not permitted: Can not accept/reject peering request in %s state.", n.Status)
+		}
+
+		if expectedStatus == "ACTIVE" {
+			_, err = peerings.Accept(peeringClient, id).ExtractResult()
+			if err != nil {
+				return fmt.Errorf("Unable to accept VPC Peering Connection: %s", err)
+			}
+		} else {
+			_, err = peerings.Reject(peeringClient, id).ExtractResult()
+			if err != nil {
+				return fmt.Errorf("Unable to reject VPC Peering Connection: %s", err)
+			}
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"PENDING"},
+		Target:     []string{expectedStatus},
+		Refresh:    waitForVpcPeeringConnStatus(peeringClient, n.ID, expectedStatus),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for VPC Peering Connection(%s) to become %s: %s", n.ID, expectedStatus, err)
+	}
+
+	d.SetId(n.ID)
+	log.Printf("[INFO] VPC Peering Connection status: %s", expectedStatus)
+
+	destinations := utils.ExpandToStringList(d.Get("accepter_routes").(*schema.Set).List())
+	if err := createVpcPeeringRoutes(peeringClient, n.AcceptVpcInfo.VpcId, n.ID, destinations); err != nil {
+		return err
+	}
+
+	return resourceVpcPeeringAccepterRead(d, meta)
+}
+
+func resourceVpcPeeringAccepterRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringclient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud peering client: %s", err)
+	}
+
+	n, err := peerings.Get(peeringclient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud Vpc Peering Connection")
+	}
+
+	d.Set("name", n.Name)
+	d.Set("status", n.Status)
+	d.Set("vpc_id", n.RequestVpcInfo.VpcId)
+	d.Set("peer_vpc_id", n.AcceptVpcInfo.VpcId)
+	d.Set("peer_tenant_id", n.AcceptVpcInfo.TenantId)
+	d.Set("vpc_peering_connection_id", n.ID)
+	d.Set("region", GetRegion(d, config))
+
+	accepterRoutes, err := listVpcPeeringRoutes(peeringclient, n.AcceptVpcInfo.VpcId, n.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("accepter_routes", vpcPeeringRouteDestinations(d, "accepter_routes", accepterRoutes))
+
+	return nil
+}
+
+func resourceVPCPeeringAccepterUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("accept") {
+		return fmt.Errorf("VPC peering action not permitted: Can not accept/reject peering request not in PENDING_ACCEPTANCE state.")
+	}
+
+	if d.HasChange("accepter_routes") {
+		if status := d.Get("status").(string); status != "ACTIVE" {
+			return fmt.Errorf("Error updating the routes of SberCloud Vpc Peering Connection(%s): "+
+				"the routes can only be added to an ACTIVE connection, got %s", d.Id(), status)
+		}
+
+		config := meta.(*config.Config)
+		peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud Peering client: %s", err)
+		}
+		if err := updateVpcPeeringRoutes(d, peeringClient, "accepter_routes", d.Get("peer_vpc_id").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceVpcPeeringAccepterRead(d, meta)
+}
+
+func resourceVPCPeeringAccepterDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	peeringClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud Peering client: %s", err)
+	}
+
+	destinations := utils.ExpandToStringList(d.Get("accepter_routes").(*schema.Set).List())
+	err = deleteVpcPeeringRoutes(peeringClient, d.Get("peer_vpc_id").(string), d.Id(), destinations)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[WARN] Will not delete VPC peering connection. Terraform will remove this resource from the state file, however resources may remain.")
+	d.SetId("")
+	return nil
+}
+
+func resourceVPCPeeringAccepterImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	peeringclient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating SberCloud peering client: %s", err)
+	}
+
+	n, err := peerings.Get(peeringclient, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving SberCloud Vpc Peering Connection %s: %s", d.Id(), err)
+	}
+
+	// manage all the routes to the connection, as they are not known from the state
+	if err := importVpcPeeringRoutes(d, peeringclient, "accepter_routes", n.AcceptVpcInfo.VpcId); err != nil {
+		return nil, err
+	}
+	if err := resourceVpcPeeringAccepterRead(d, meta); err != nil {
+		return nil, err
+	}
+	d.Set("accept", d.Get("status").(string) == "ACTIVE")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func waitForVpcPeeringConnStatus(peeringClient *golangsdk.ServiceClient, peeringId, expectedStatus string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := peerings.Get(peeringClient, peeringId).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if n.Status == expectedStatus {
+			return n, expectedStatus, nil
+		}
+
+		return n, "PENDING", nil
+	}
+}
@@ -30,12 +30,15 @@ func TestAccVpcPeeringConnectionV2_basic(t *testing.T) {
 					testAccCheckVpcPeeringConnectionV2Exists(resourceName, &peering),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "requester_routes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "accepter_routes.#", "1"),
 				),
 			},
 			{
 				Config: testAccVpcPeeringConnectionV2_basic(rNameUpdate),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", rNameUpdate),
+					resource.TestCheckResourceAttr(resourceName, "requester_routes.#", "1"),
 				),
 			},
 			{
@@ -47,6 +50,33 @@ func TestAccVpcPeeringConnectionV2_basic(t *testing.T) {
 	})
 }
 
+func TestAccVpcPeeringConnectionV2_crossTenant(t *testing.T) {
+	var peering peerings.Peering
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc_peering_connection.test"
+	accepterName := "sbercloud_vpc_peering_connection_accepter.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckPeerTenant(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcPeeringConnectionV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcPeeringConnectionV2_crossTenant(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcPeeringConnectionV2Exists(resourceName, &peering),
+					resource.TestCheckResourceAttr(resourceName, "peer_tenant_id", SBC_PEER_PROJECT_ID),
+					resource.TestCheckResourceAttr(accepterName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttrPair(accepterName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(accepterName, "accepter_routes.#", "1"),
+					resource.TestCheckResourceAttrPair("sbercloud_vpc_route.test", "nexthop", resourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcPeeringConnectionV2Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	peeringClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
@@ -109,13 +139,60 @@ resource "sbercloud_vpc" "test" {
 
 resource "sbercloud_vpc" "test2" {
   name = "%s"
+  cidr = "172.16.0.0/16"
+}
+
+resource "sbercloud_vpc_peering_connection" "test" {
+  name             = "%s"
+  vpc_id           = sbercloud_vpc.test.id
+  peer_vpc_id      = sbercloud_vpc.test2.id
+  requester_routes = [sbercloud_vpc.test2.cidr]
+  accepter_routes  = [sbercloud_vpc.test.cidr]
+}
+`, rName, rName+"2", rName)
+}
+
+func testAccVpcPeeringConnectionV2_crossTenant(rName string) string {
+	return fmt.Sprintf(`
+provider "sbercloud" {
+  alias      = "peer"
+  region     = "%[2]s"
+  access_key = "%[3]s"
+  secret_key = "%[4]s"
+}
+
+resource "sbercloud_vpc" "test" {
+  name = "%[1]s"
   cidr = "192.168.0.0/16"
 }
 
+resource "sbercloud_vpc" "peer" {
+  provider = sbercloud.peer
+
+  name = "%[1]s-peer"
+  cidr = "172.16.0.0/16"
+}
+
 resource "sbercloud_vpc_peering_connection" "test" {
-  name        = "%s"
+  name           = "%[1]s"
+  vpc_id         = sbercloud_vpc.test.id
+  peer_vpc_id    = sbercloud_vpc.peer.id
+  peer_tenant_id = "%[5]s"
+}
+
+resource "sbercloud_vpc_peering_connection_accepter" "test" {
+  provider = sbercloud.peer
+
+  vpc_peering_connection_id = sbercloud_vpc_peering_connection.test.id
+  accept                    = true
+  accepter_routes           = [sbercloud_vpc.test.cidr]
+}
+
+resource "sbercloud_vpc_route" "test" {
+  type        = "peering"
+  nexthop     = sbercloud_vpc_peering_connection_accepter.test.id
+  destination = sbercloud_vpc.peer.cidr
   vpc_id      = sbercloud_vpc.test.id
-  peer_vpc_id = sbercloud_vpc.test2.id
 }
-`, rName, rName+"2", rName)
+`, rName, SBC_REGION_NAME, SBC_PEER_ACCESS_KEY, SBC_PEER_SECRET_KEY, SBC_PEER_PROJECT_ID)
 }
@@ -33,12 +33,14 @@ func TestAccVpcSubnetV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "gateway_ip", "192.168.0.1"),
 					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
 					resource.TestCheckResourceAttr(resourceName, "tags.key", "value"),
+					resource.TestCheckResourceAttr(resourceName, "description", "created by acc test"),
 				),
 			},
 			{
 				Config: testAccVpcSubnetV1_update(rNameUpdate),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", rNameUpdate),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated by acc test"),
 					resource.TestCheckResourceAttr(resourceName, "tags.key", "value_updated"),
 				),
 			},
@@ -118,6 +120,7 @@ resource "sbercloud_vpc_subnet" "test" {
   gateway_ip = "192.168.0.1"
   vpc_id     = sbercloud_vpc.test.id
 
+  description       = "created by acc test"
   availability_zone = data.sbercloud_availability_zones.test.names[0]
 
   tags = {
@@ -143,6 +146,7 @@ resource "sbercloud_vpc_subnet" "test" {
   gateway_ip = "192.168.0.1"
   vpc_id     = sbercloud_vpc.test.id
 
+  description       = "updated by acc test"
   availability_zone = data.sbercloud_availability_zones.test.names[0]
 
   tags = {
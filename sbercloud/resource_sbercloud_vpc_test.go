@@ -77,6 +77,51 @@ func TestAccVpcV1_WithEpsId(t *testing.T) {
 	})
 }
 
+func TestAccVpcV1_secondaryCidrs(t *testing.T) {
+	var vpc vpcs.Vpc
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpc.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcV1_secondaryCidrs(rName, "172.16.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "description", "created by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "secondary_cidrs.#", "1"),
+					resource.TestCheckResourceAttr("sbercloud_vpc_subnet.test", "cidr", "172.16.0.0/24"),
+				),
+			},
+			{
+				Config: testAccVpcV1_secondaryCidrs(rName, "172.17.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "secondary_cidrs.#", "1"),
+					resource.TestCheckResourceAttr("sbercloud_vpc_subnet.test", "cidr", "172.17.0.0/24"),
+				),
+			},
+			{
+				Config: testAccVpcV1_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "secondary_cidrs.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckVpcV1Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	vpcClient, err := config.NetworkingV1Client(SBC_REGION_NAME)
@@ -167,3 +212,21 @@ resource "sbercloud_vpc" "test" {
 }
 `, rName, SBC_ENTERPRISE_PROJECT_ID_TEST)
 }
+
+func testAccVpcV1_secondaryCidrs(rName, secondaryCidr string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "test" {
+  name            = "%[1]s"
+  cidr            = "192.168.0.0/16"
+  description     = "created by acc test"
+  secondary_cidrs = ["%[2]s"]
+}
+
+resource "sbercloud_vpc_subnet" "test" {
+  name       = "%[1]s"
+  cidr       = cidrsubnet("%[2]s", 8, 0)
+  gateway_ip = cidrhost(cidrsubnet("%[2]s", 8, 0), 1)
+  vpc_id     = sbercloud_vpc.test.id
+}
+`, rName, secondaryCidr)
+}
@@ -0,0 +1,82 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+
+	"github.com/huaweicloud/golangsdk/openstack/vpcep/v1/endpoints"
+	"github.com/huaweicloud/golangsdk/openstack/vpcep/v1/services"
+)
+
+func TestAccVPCEPApproval_basic(t *testing.T) {
+	var service services.Service
+	var endpoint endpoints.Endpoint
+
+	rName := fmt.Sprintf("acc-test-%s", acctest.RandString(4))
+	resourceName := "sbercloud_vpcep_approval.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVPCEPServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCEPApproval_basic(rName, "sbercloud_vpcep_endpoint.test.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCEPServiceExists("sbercloud_vpcep_service.test", &service),
+					testAccCheckVPCEPEndpointExists("sbercloud_vpcep_endpoint.test", &endpoint),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &service.ID),
+					resource.TestCheckResourceAttrPtr(resourceName, "connections.0.endpoint_id", &endpoint.ID),
+					resource.TestCheckResourceAttr(resourceName, "connections.0.status", "accepted"),
+				),
+			},
+			{
+				Config: testAccVPCEPApproval_basic(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "connections.0.endpoint_id", &endpoint.ID),
+					resource.TestCheckResourceAttr(resourceName, "connections.0.status", "rejected"),
+				),
+			},
+		},
+	})
+}
+
+// testAccVPCEPApproval_basic accepts the connection of the endpoint
+// referenced by endpointRef and rejects it when endpointRef is empty.
+func testAccVPCEPApproval_basic(rName, endpointRef string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_vpcep_service" "test" {
+  name        = "%s"
+  server_type = "VM"
+  vpc_id      = data.sbercloud_vpc.test.id
+  port_id     = sbercloud_compute_instance.test.network[0].port
+  approval    = true
+
+  port_mapping {
+    service_port  = 8080
+    terminal_port = 80
+  }
+}
+
+resource "sbercloud_vpcep_endpoint" "test" {
+  service_id = sbercloud_vpcep_service.test.id
+  vpc_id     = data.sbercloud_vpc.test.id
+  network_id = data.sbercloud_vpc_subnet.test.id
+  enable_dns = true
+
+  lifecycle {
+    ignore_changes = [enable_dns]
+  }
+}
+
+resource "sbercloud_vpcep_approval" "test" {
+  service_id = sbercloud_vpcep_service.test.id
+  endpoints  = [%s]
+}
+`, testAccVPCEPService_base(rName), rName, endpointRef)
+}
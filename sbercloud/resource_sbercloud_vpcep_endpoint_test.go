@@ -0,0 +1,190 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/vpcep/v1/endpoints"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVPCEPEndpoint_basic(t *testing.T) {
+	var endpoint endpoints.Endpoint
+
+	rName := fmt.Sprintf("acc-test-%s", acctest.RandString(4))
+	resourceName := "sbercloud_vpcep_endpoint.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVPCEPEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCEPEndpoint_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCEPEndpointExists(resourceName, &endpoint),
+					resource.TestCheckResourceAttr(resourceName, "status", "accepted"),
+					resource.TestCheckResourceAttr(resourceName, "enable_dns", "true"),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "interface"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "tf-acc"),
+					resource.TestCheckResourceAttrSet(resourceName, "service_name"),
+					resource.TestCheckResourceAttrSet(resourceName, "private_domain_name"),
+				),
+			},
+			{
+				Config: testAccVPCEPEndpoint_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "accepted"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "tf-acc-update"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVPCEPEndpoint_public(t *testing.T) {
+	var endpoint endpoints.Endpoint
+	resourceName := "sbercloud_vpcep_endpoint.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVPCEPEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCEPEndpoint_public,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCEPEndpointExists(resourceName, &endpoint),
+					resource.TestCheckResourceAttr(resourceName, "status", "accepted"),
+					resource.TestCheckResourceAttr(resourceName, "enable_dns", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enable_whitelist", "true"),
+					resource.TestCheckResourceAttr(resourceName, "whitelist.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "private_domain_name"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVPCEPEndpointDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	vpcepClient, err := config.VPCEPClient(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating VPC endpoint client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpcep_endpoint" {
+			continue
+		}
+
+		_, err := endpoints.Get(vpcepClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("VPC endpoint still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVPCEPEndpointExists(n string, endpoint *endpoints.Endpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		vpcepClient, err := config.VPCEPClient(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating VPC endpoint client: %s", err)
+		}
+
+		found, err := endpoints.Get(vpcepClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC endpoint not found")
+		}
+
+		*endpoint = *found
+
+		return nil
+	}
+}
+
+func testAccVPCEPEndpoint_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_vpcep_endpoint" "test" {
+  service_id = sbercloud_vpcep_service.test.id
+  vpc_id     = data.sbercloud_vpc.test.id
+  network_id = data.sbercloud_vpc_subnet.test.id
+  enable_dns = true
+
+  tags = {
+    owner = "tf-acc"
+  }
+}
+`, testAccVPCEPService_basic(rName))
+}
+
+func testAccVPCEPEndpoint_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_vpcep_endpoint" "test" {
+  service_id = sbercloud_vpcep_service.test.id
+  vpc_id     = data.sbercloud_vpc.test.id
+  network_id = data.sbercloud_vpc_subnet.test.id
+  enable_dns = true
+
+  tags = {
+    owner = "tf-acc-update"
+    foo   = "bar"
+  }
+}
+`, testAccVPCEPService_basic(rName))
+}
+
+const testAccVPCEPEndpoint_public = `
+data "sbercloud_vpc" "test" {
+  name = "vpc-default"
+}
+
+data "sbercloud_vpc_subnet" "test" {
+  vpc_id = data.sbercloud_vpc.test.id
+  name   = "subnet-default"
+}
+
+data "sbercloud_vpcep_public_services" "obs" {
+  service_name = "obs"
+}
+
+resource "sbercloud_vpcep_endpoint" "test" {
+  service_id       = data.sbercloud_vpcep_public_services.obs.services[0].id
+  vpc_id           = data.sbercloud_vpc.test.id
+  network_id       = data.sbercloud_vpc_subnet.test.id
+  enable_dns       = true
+  enable_whitelist = true
+  whitelist        = ["192.168.0.0/24", "10.10.10.10"]
+}
+`
@@ -0,0 +1,181 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/vpcep/v1/services"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVPCEPService_basic(t *testing.T) {
+	var service services.Service
+
+	rName := fmt.Sprintf("acc-test-%s", acctest.RandString(4))
+	resourceName := "sbercloud_vpcep_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVPCEPServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCEPService_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCEPServiceExists(resourceName, &service),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+					resource.TestCheckResourceAttr(resourceName, "approval", "false"),
+					resource.TestCheckResourceAttr(resourceName, "port_mapping.0.service_port", "8080"),
+					resource.TestCheckResourceAttr(resourceName, "port_mapping.0.terminal_port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "tf-acc"),
+				),
+			},
+			{
+				Config: testAccVPCEPService_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-"+rName),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+					resource.TestCheckResourceAttr(resourceName, "approval", "true"),
+					resource.TestCheckResourceAttr(resourceName, "port_mapping.0.service_port", "8088"),
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "tf-acc-update"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVPCEPServiceDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	vpcepClient, err := config.VPCEPClient(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating VPC endpoint client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpcep_service" {
+			continue
+		}
+
+		_, err := services.Get(vpcepClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("VPC endpoint service still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVPCEPServiceExists(n string, service *services.Service) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		vpcepClient, err := config.VPCEPClient(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating VPC endpoint client: %s", err)
+		}
+
+		found, err := services.Get(vpcepClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC endpoint service not found")
+		}
+
+		*service = *found
+
+		return nil
+	}
+}
+
+// testAccVPCEPService_base creates an instance in the default VPC to serve
+// behind the VPC endpoint service.
+func testAccVPCEPService_base(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_vpc" "test" {
+  name = "vpc-default"
+}
+
+resource "sbercloud_compute_instance" "test" {
+  name              = "%s"
+  image_id          = data.sbercloud_images_image.test.id
+  flavor_id         = data.sbercloud_compute_flavors.test.ids[0]
+  security_groups   = ["default"]
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  system_disk_type  = "SSD"
+
+  network {
+    uuid = data.sbercloud_vpc_subnet.test.id
+  }
+}
+`, testAccCompute_data, rName)
+}
+
+func testAccVPCEPService_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_vpcep_service" "test" {
+  name        = "%s"
+  server_type = "VM"
+  vpc_id      = data.sbercloud_vpc.test.id
+  port_id     = sbercloud_compute_instance.test.network[0].port
+  approval    = false
+
+  port_mapping {
+    service_port  = 8080
+    terminal_port = 80
+  }
+
+  tags = {
+    owner = "tf-acc"
+  }
+}
+`, testAccVPCEPService_base(rName), rName)
+}
+
+func testAccVPCEPService_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_vpcep_service" "test" {
+  name        = "tf-%s"
+  server_type = "VM"
+  vpc_id      = data.sbercloud_vpc.test.id
+  port_id     = sbercloud_compute_instance.test.network[0].port
+  approval    = true
+  permissions = ["iam:domain::abcd"]
+
+  port_mapping {
+    service_port  = 8088
+    terminal_port = 80
+  }
+
+  tags = {
+    owner = "tf-acc-update"
+  }
+}
+`, testAccVPCEPService_base(rName), rName)
+}
@@ -0,0 +1,112 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpnEndpointGroupV2_basic(t *testing.T) {
+	var group endpointgroups.EndpointGroup
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpnaas_endpoint_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnEndpointGroupV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnEndpointGroupV2_basic(rName, `"10.2.0.0/24", "10.3.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnEndpointGroupV2Exists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "cidr"),
+					resource.TestCheckResourceAttr(resourceName, "endpoints.#", "2"),
+				),
+			},
+			{
+				Config: testAccVpnEndpointGroupV2_basic(rName+"-update", `"10.2.0.0/24", "10.3.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnEndpointGroupV2Exists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpnEndpointGroupV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpnaas_endpoint_group" {
+			continue
+		}
+
+		_, err = endpointgroups.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Endpoint group (%s) still exists", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpnEndpointGroupV2Exists(n string, group *endpointgroups.EndpointGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := endpointgroups.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		*group = *found
+
+		return nil
+	}
+}
+
+func testAccVpnEndpointGroupV2_basic(rName, endpoints string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpnaas_endpoint_group" "test" {
+  name      = "%s"
+  type      = "cidr"
+  endpoints = [%s]
+}
+`, rName, endpoints)
+}
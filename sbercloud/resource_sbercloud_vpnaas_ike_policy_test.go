@@ -0,0 +1,130 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpnIKEPolicyV2_basic(t *testing.T) {
+	var policy ikepolicies.Policy
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpnaas_ike_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnIKEPolicyV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnIKEPolicyV2_basic(rName, 86400),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIKEPolicyV2Exists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "auth_algorithm", "sha2-256"),
+					resource.TestCheckResourceAttr(resourceName, "pfs", "group14"),
+					resource.TestCheckResourceAttr(resourceName, "ike_version", "v2"),
+				),
+			},
+			{
+				Config: testAccVpnIKEPolicyV2_basic(rName+"-update", 28800),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIKEPolicyV2Exists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					testAccCheckVpnIKEPolicyV2Lifetime(&policy, 28800),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpnIKEPolicyV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpnaas_ike_policy" {
+			continue
+		}
+
+		_, err = ikepolicies.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("IKE policy (%s) still exists", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpnIKEPolicyV2Exists(n string, policy *ikepolicies.Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := ikepolicies.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		*policy = *found
+
+		return nil
+	}
+}
+
+func testAccCheckVpnIKEPolicyV2Lifetime(policy *ikepolicies.Policy, lifetime int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if policy.Lifetime.Value != lifetime {
+			return fmt.Errorf("Expected the lifetime %d, got %d", lifetime, policy.Lifetime.Value)
+		}
+		return nil
+	}
+}
+
+func testAccVpnIKEPolicyV2_basic(rName string, lifetime int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpnaas_ike_policy" "test" {
+  name                 = "%s"
+  auth_algorithm       = "sha2-256"
+  encryption_algorithm = "aes-128"
+  pfs                  = "group14"
+  ike_version          = "v2"
+
+  lifetime {
+    units = "seconds"
+    value = %d
+  }
+}
+`, rName, lifetime)
+}
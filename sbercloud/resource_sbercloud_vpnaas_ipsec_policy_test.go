@@ -0,0 +1,129 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpnIPSecPolicyV2_basic(t *testing.T) {
+	var policy ipsecpolicies.Policy
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpnaas_ipsec_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnIPSecPolicyV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnIPSecPolicyV2_basic(rName, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIPSecPolicyV2Exists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "auth_algorithm", "sha2-256"),
+					resource.TestCheckResourceAttr(resourceName, "transform_protocol", "esp"),
+				),
+			},
+			{
+				Config: testAccVpnIPSecPolicyV2_basic(rName+"-update", 7200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIPSecPolicyV2Exists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					testAccCheckVpnIPSecPolicyV2Lifetime(&policy, 7200),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpnIPSecPolicyV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpnaas_ipsec_policy" {
+			continue
+		}
+
+		_, err = ipsecpolicies.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("IPsec policy (%s) still exists", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpnIPSecPolicyV2Exists(n string, policy *ipsecpolicies.Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := ipsecpolicies.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		*policy = *found
+
+		return nil
+	}
+}
+
+func testAccCheckVpnIPSecPolicyV2Lifetime(policy *ipsecpolicies.Policy, lifetime int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if policy.Lifetime.Value != lifetime {
+			return fmt.Errorf("Expected the lifetime %d, got %d", lifetime, policy.Lifetime.Value)
+		}
+		return nil
+	}
+}
+
+func testAccVpnIPSecPolicyV2_basic(rName string, lifetime int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpnaas_ipsec_policy" "test" {
+  name                 = "%s"
+  auth_algorithm       = "sha2-256"
+  encryption_algorithm = "aes-128"
+  pfs                  = "group14"
+  transform_protocol   = "esp"
+
+  lifetime {
+    units = "seconds"
+    value = %d
+  }
+}
+`, rName, lifetime)
+}
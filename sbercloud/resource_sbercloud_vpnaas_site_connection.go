@@ -0,0 +1,481 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/common/tags"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/vpnaas/siteconnections"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func ResourceVpnSiteConnectionV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnSiteConnectionV2Create,
+		Read:   resourceVpnSiteConnectionV2Read,
+		Update: resourceVpnSiteConnectionV2Update,
+		Delete: resourceVpnSiteConnectionV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ikepolicy_id": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"peer_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"peer_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"peer_ep_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"local_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vpnservice_id": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"local_ep_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ipsecpolicy_id": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"psk": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"initiator": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"bi-directional", "response-only",
+				}, false),
+			},
+			"mtu": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"tenant_id": {
+				Type:       schema.TypeString,
+				Optional:   true,
+				ForceNew:   true,
+				Computed:   true,
+				Deprecated: "tenant_id is deprecated",
+			},
+			"peer_cidrs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"dpd": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"hold", "clear", "restart", "disabled", "restart-by-peer",
+							}, false),
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Computed:     true,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Computed:     true,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"value_specs": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": tagsSchema(),
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnSiteConnectionV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	dpd := resourceSiteConnectionV2DPDCreateOpts(d.Get("dpd").(*schema.Set))
+	adminStateUp := d.Get("admin_state_up").(bool)
+	initiator := siteconnections.Initiator(d.Get("initiator").(string))
+
+	createOpts := huaweicloud.VpnSiteConnectionCreateOpts{
+		CreateOpts: siteconnections.CreateOpts{
+			Name:           d.Get("name").(string),
+			Description:    d.Get("description").(string),
+			AdminStateUp:   &adminStateUp,
+			Initiator:      initiator,
+			IKEPolicyID:    d.Get("ikepolicy_id").(string),
+			TenantID:       d.Get("tenant_id").(string),
+			PeerID:         d.Get("peer_id").(string),
+			PeerAddress:    d.Get("peer_address").(string),
+			PeerEPGroupID:  d.Get("peer_ep_group_id").(string),
+			LocalID:        d.Get("local_id").(string),
+			VPNServiceID:   d.Get("vpnservice_id").(string),
+			LocalEPGroupID: d.Get("local_ep_group_id").(string),
+			IPSecPolicyID:  d.Get("ipsecpolicy_id").(string),
+			PSK:            d.Get("psk").(string),
+			MTU:            d.Get("mtu").(int),
+			PeerCIDRs:      expandVpnSiteConnectionPeerCIDRs(d),
+			DPD:            &dpd,
+		},
+		ValueSpecs: huaweicloud.MapValueSpecs(d),
+	}
+
+	// the PSK is not logged with the options
+	log.Printf("[DEBUG] Create SberCloud VPN site connection %s to %s",
+		createOpts.Name, createOpts.PeerAddress)
+	conn, err := siteconnections.Create(networkingClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud VPN site connection: %s", err)
+	}
+
+	d.SetId(conn.ID)
+
+	log.Printf("[DEBUG] Waiting for SberCloud VPN site connection (%s) to become active", d.Id())
+	if err := waitForVpnSiteConnectionActive(d, networkingClient, schema.TimeoutCreate); err != nil {
+		return err
+	}
+
+	// create tags
+	tagRaw := d.Get("tags").(map[string]interface{})
+	if len(tagRaw) > 0 {
+		taglist := utils.ExpandResourceTags(tagRaw)
+		if tagErr := tags.Create(networkingClient, "ipsec-site-connections", d.Id(), taglist).ExtractErr(); tagErr != nil {
+			return fmt.Errorf("Error setting tags of VPN site connection %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	return resourceVpnSiteConnectionV2Read(d, meta)
+}
+
+func resourceVpnSiteConnectionV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	conn, err := siteconnections.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving SberCloud VPN site connection")
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", conn.Name)
+	d.Set("description", conn.Description)
+	d.Set("admin_state_up", conn.AdminStateUp)
+	d.Set("tenant_id", conn.TenantID)
+	d.Set("initiator", conn.Initiator)
+	d.Set("ikepolicy_id", conn.IKEPolicyID)
+	d.Set("peer_id", conn.PeerID)
+	d.Set("peer_address", conn.PeerAddress)
+	d.Set("local_id", conn.LocalID)
+	d.Set("peer_ep_group_id", conn.PeerEPGroupID)
+	d.Set("vpnservice_id", conn.VPNServiceID)
+	d.Set("local_ep_group_id", conn.LocalEPGroupID)
+	d.Set("ipsecpolicy_id", conn.IPSecPolicyID)
+	// Do not set psk here as the response value is masked
+	d.Set("mtu", conn.MTU)
+	d.Set("peer_cidrs", conn.PeerCIDRs)
+	d.Set("status", conn.Status)
+
+	dpd := []map[string]interface{}{
+		{
+			"action":   conn.DPD.Action,
+			"interval": conn.DPD.Interval,
+			"timeout":  conn.DPD.Timeout,
+		},
+	}
+	if err := d.Set("dpd", dpd); err != nil {
+		return fmt.Errorf("Error saving dpd of VPN site connection %s: %s", d.Id(), err)
+	}
+
+	resourceTags, err := tags.Get(networkingClient, "ipsec-site-connections", d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("Error fetching VPN site connection tags: %s", err)
+	}
+	if err := d.Set("tags", utils.TagsToMap(resourceTags.Tags)); err != nil {
+		return fmt.Errorf("Error saving tags for VPN site connection %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceVpnSiteConnectionV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	opts := siteconnections.UpdateOpts{}
+	var hasChange bool
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		opts.Name = &name
+		hasChange = true
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		opts.Description = &description
+		hasChange = true
+	}
+	if d.HasChange("admin_state_up") {
+		adminStateUp := d.Get("admin_state_up").(bool)
+		opts.AdminStateUp = &adminStateUp
+		hasChange = true
+	}
+	if d.HasChange("local_id") {
+		opts.LocalID = d.Get("local_id").(string)
+		hasChange = true
+	}
+	if d.HasChange("peer_address") {
+		opts.PeerAddress = d.Get("peer_address").(string)
+		hasChange = true
+	}
+	if d.HasChange("peer_id") {
+		opts.PeerID = d.Get("peer_id").(string)
+		hasChange = true
+	}
+	if d.HasChange("local_ep_group_id") {
+		opts.LocalEPGroupID = d.Get("local_ep_group_id").(string)
+		hasChange = true
+	}
+	if d.HasChange("peer_ep_group_id") {
+		opts.PeerEPGroupID = d.Get("peer_ep_group_id").(string)
+		hasChange = true
+	}
+	if d.HasChange("psk") {
+		opts.PSK = d.Get("psk").(string)
+		hasChange = true
+	}
+	if d.HasChange("mtu") {
+		opts.MTU = d.Get("mtu").(int)
+		hasChange = true
+	}
+	if d.HasChange("initiator") {
+		opts.Initiator = siteconnections.Initiator(d.Get("initiator").(string))
+		hasChange = true
+	}
+	if d.HasChange("peer_cidrs") {
+		opts.PeerCIDRs = expandVpnSiteConnectionPeerCIDRs(d)
+		hasChange = true
+	}
+	if d.HasChange("dpd") {
+		dpd := resourceSiteConnectionV2DPDUpdateOpts(d.Get("dpd").(*schema.Set))
+		opts.DPD = &dpd
+		hasChange = true
+	}
+
+	if hasChange {
+		log.Printf("[DEBUG] Updating SberCloud VPN site connection %s", d.Id())
+		_, err = siteconnections.Update(networkingClient, d.Id(), opts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating SberCloud VPN site connection %s: %s", d.Id(), err)
+		}
+
+		if err := waitForVpnSiteConnectionActive(d, networkingClient, schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	}
+
+	tagErr := utils.UpdateResourceTags(networkingClient, d, "ipsec-site-connections", d.Id())
+	if tagErr != nil {
+		return fmt.Errorf("Error updating tags of VPN site connection %s: %s", d.Id(), tagErr)
+	}
+
+	return resourceVpnSiteConnectionV2Read(d, meta)
+}
+
+func resourceVpnSiteConnectionV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	err = siteconnections.Delete(networkingClient, d.Id()).ExtractErr()
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting SberCloud VPN site connection")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"DELETING"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForSiteConnectionDeletion(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for SberCloud VPN site connection (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForVpnSiteConnectionActive waits until the IPsec tunnel of the connection
+// is established with the peer gateway, or until it is DOWN when the connection
+// is administratively disabled.
+func waitForVpnSiteConnectionActive(d *schema.ResourceData, client *golangsdk.ServiceClient, timeout string) error {
+	pending := []string{"PENDING_CREATE", "PENDING_UPDATE", "DOWN"}
+	target := []string{"ACTIVE"}
+	if !d.Get("admin_state_up").(bool) {
+		pending = []string{"PENDING_CREATE", "PENDING_UPDATE", "ACTIVE"}
+		target = []string{"DOWN"}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    resourceVpnSiteConnectionV2RefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(timeout),
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for SberCloud VPN site connection (%s) to become active: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceVpnSiteConnectionV2RefreshFunc(client *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		conn, err := siteconnections.Get(client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if conn.Status == "ERROR" {
+			return conn, conn.Status, fmt.Errorf("the VPN site connection is in ERROR status")
+		}
+		return conn, conn.Status, nil
+	}
+}
+
+func waitForSiteConnectionDeletion(client *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		conn, err := siteconnections.Get(client, id).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] Successfully deleted SberCloud VPN site connection %s", id)
+				return "", "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		return conn, "DELETING", nil
+	}
+}
+
+func expandVpnSiteConnectionPeerCIDRs(d *schema.ResourceData) []string {
+	raw := d.Get("peer_cidrs").([]interface{})
+	peerCIDRs := make([]string, len(raw))
+	for i, v := range raw {
+		peerCIDRs[i] = v.(string)
+	}
+	return peerCIDRs
+}
+
+func resourceSiteConnectionV2DPDCreateOpts(d *schema.Set) siteconnections.DPDCreateOpts {
+	dpd := siteconnections.DPDCreateOpts{}
+	for _, raw := range d.List() {
+		rawMap := raw.(map[string]interface{})
+		dpd.Action = siteconnections.Action(rawMap["action"].(string))
+		dpd.Timeout = rawMap["timeout"].(int)
+		dpd.Interval = rawMap["interval"].(int)
+	}
+	return dpd
+}
+
+func resourceSiteConnectionV2DPDUpdateOpts(d *schema.Set) siteconnections.DPDUpdateOpts {
+	dpd := siteconnections.DPDUpdateOpts{}
+	for _, raw := range d.List() {
+		rawMap := raw.(map[string]interface{})
+		dpd.Action = siteconnections.Action(rawMap["action"].(string))
+		dpd.Timeout = rawMap["timeout"].(int)
+		dpd.Interval = rawMap["interval"].(int)
+	}
+	return dpd
+}
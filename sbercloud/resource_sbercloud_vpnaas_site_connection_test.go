@@ -0,0 +1,227 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/vpnaas/siteconnections"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccVpnSiteConnectionV2_basic(t *testing.T) {
+	var conn siteconnections.Connection
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_vpnaas_site_connection.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnSiteConnectionV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnSiteConnectionV2_basic(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnSiteConnectionV2Exists(resourceName, &conn),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "dpd.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+					testAccCheckVpnSiteConnectionV2DPD(&conn, "restart", 30),
+				),
+			},
+			{
+				Config: testAccVpnSiteConnectionV2_basic(rName, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnSiteConnectionV2Exists(resourceName, &conn),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					testAccCheckVpnSiteConnectionV2DPD(&conn, "restart", 60),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"psk"},
+			},
+		},
+	})
+}
+
+func testAccCheckVpnSiteConnectionV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_vpnaas_site_connection" {
+			continue
+		}
+
+		_, err = siteconnections.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Site connection (%s) still exists", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpnSiteConnectionV2Exists(n string, conn *siteconnections.Connection) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		networkingClient, err := config.NetworkingV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud networking client: %s", err)
+		}
+
+		found, err := siteconnections.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		*conn = *found
+
+		return nil
+	}
+}
+
+func testAccCheckVpnSiteConnectionV2DPD(conn *siteconnections.Connection, action string, interval int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if conn.DPD.Action != action || conn.DPD.Interval != interval {
+			return fmt.Errorf("Expected DPD %s every %d seconds, got %#v", action, interval, conn.DPD)
+		}
+		return nil
+	}
+}
+
+// testAccVpnSiteConnectionV2_basic connects two VPN gateways in two VPCs to
+// each other, so that the tunnel of the tested connection becomes ACTIVE.
+func testAccVpnSiteConnectionV2_basic(rName string, dpdInterval int) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "left" {
+  name = "%[1]s-left"
+  cidr = "192.168.0.0/16"
+}
+
+resource "sbercloud_vpc_subnet" "left" {
+  name       = "%[1]s-left"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = sbercloud_vpc.left.id
+}
+
+resource "sbercloud_vpc" "right" {
+  name = "%[1]s-right"
+  cidr = "172.16.0.0/16"
+}
+
+resource "sbercloud_vpc_subnet" "right" {
+  name       = "%[1]s-right"
+  cidr       = "172.16.0.0/24"
+  gateway_ip = "172.16.0.1"
+  vpc_id     = sbercloud_vpc.right.id
+}
+
+resource "sbercloud_vpnaas_service" "left" {
+  name      = "%[1]s-left"
+  router_id = sbercloud_vpc.left.id
+}
+
+resource "sbercloud_vpnaas_service" "right" {
+  name      = "%[1]s-right"
+  router_id = sbercloud_vpc.right.id
+}
+
+resource "sbercloud_vpnaas_ike_policy" "test" {
+  name                 = "%[1]s"
+  auth_algorithm       = "sha2-256"
+  encryption_algorithm = "aes-128"
+  pfs                  = "group14"
+}
+
+resource "sbercloud_vpnaas_ipsec_policy" "test" {
+  name                 = "%[1]s"
+  auth_algorithm       = "sha2-256"
+  encryption_algorithm = "aes-128"
+  pfs                  = "group14"
+}
+
+resource "sbercloud_vpnaas_endpoint_group" "left_local" {
+  name      = "%[1]s-left-local"
+  type      = "subnet"
+  endpoints = [sbercloud_vpc_subnet.left.subnet_id]
+}
+
+resource "sbercloud_vpnaas_endpoint_group" "left_peer" {
+  name      = "%[1]s-left-peer"
+  type      = "cidr"
+  endpoints = [sbercloud_vpc_subnet.right.cidr]
+}
+
+resource "sbercloud_vpnaas_endpoint_group" "right_local" {
+  name      = "%[1]s-right-local"
+  type      = "subnet"
+  endpoints = [sbercloud_vpc_subnet.right.subnet_id]
+}
+
+resource "sbercloud_vpnaas_endpoint_group" "right_peer" {
+  name      = "%[1]s-right-peer"
+  type      = "cidr"
+  endpoints = [sbercloud_vpc_subnet.left.cidr]
+}
+
+resource "sbercloud_vpnaas_site_connection" "test" {
+  name              = "%[1]s"
+  ikepolicy_id      = sbercloud_vpnaas_ike_policy.test.id
+  ipsecpolicy_id    = sbercloud_vpnaas_ipsec_policy.test.id
+  vpnservice_id     = sbercloud_vpnaas_service.left.id
+  psk               = "Tf-acc-test-psk-1"
+  peer_address      = sbercloud_vpnaas_service.right.external_v4_ip
+  peer_id           = sbercloud_vpnaas_service.right.external_v4_ip
+  local_ep_group_id = sbercloud_vpnaas_endpoint_group.left_local.id
+  peer_ep_group_id  = sbercloud_vpnaas_endpoint_group.left_peer.id
+
+  dpd {
+    action   = "restart"
+    interval = %[2]d
+    timeout  = 120
+  }
+
+  tags = {
+    foo = "bar"
+  }
+}
+
+resource "sbercloud_vpnaas_site_connection" "peer" {
+  name              = "%[1]s-peer"
+  ikepolicy_id      = sbercloud_vpnaas_ike_policy.test.id
+  ipsecpolicy_id    = sbercloud_vpnaas_ipsec_policy.test.id
+  vpnservice_id     = sbercloud_vpnaas_service.right.id
+  psk               = "Tf-acc-test-psk-1"
+  peer_address      = sbercloud_vpnaas_service.left.external_v4_ip
+  peer_id           = sbercloud_vpnaas_service.left.external_v4_ip
+  local_ep_group_id = sbercloud_vpnaas_endpoint_group.right_local.id
+  peer_ep_group_id  = sbercloud_vpnaas_endpoint_group.right_peer.id
+}
+`, rName, dpdInterval)
+}
@@ -0,0 +1,310 @@
+package sbercloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	stubProjectID = "0970dd7a1300f5672ff2c003c60ae115"
+	stubAccessKey = "stub-access-key"
+)
+
+// apiStub is a minimal in-memory stand-in for the CCE, SMN and OBS APIs, for
+// the unit tests of the resources which cannot be tested against SberCloud.
+//
+// JSON objects posted to a collection are stored under <collection>/<key>. The
+// key is the name of the object in the Kubernetes API of a cluster, and its
+// generated UID in the CCE API. A GET of a path without an object lists the
+// objects below it, if there are any. OBS requests address the sub-resources
+// of the buckets in the path style.
+type apiStub struct {
+	mu            sync.Mutex
+	objects       map[string]map[string]interface{}
+	buckets       map[string]map[string][]byte
+	topicPolicies map[string]string
+	hosts         []string
+	counter       int
+
+	// failCCE, if set, returns the status of an error response to a CCE
+	// request, or 0 to serve the request.
+	failCCE func(method, path string) int
+	// afterCCE, if set, is called with the lock held after a CCE request
+	// changed the object at path, to simulate the effects of the change.
+	afterCCE func(method, path string, obj map[string]interface{})
+}
+
+func newAPIStub(buckets ...string) *apiStub {
+	stub := &apiStub{
+		objects:       make(map[string]map[string]interface{}),
+		buckets:       make(map[string]map[string][]byte),
+		topicPolicies: make(map[string]string),
+	}
+	for _, bucket := range buckets {
+		stub.buckets[bucket] = make(map[string][]byte)
+	}
+	return stub
+}
+
+// newAPIStubConfig starts a server for the stub and returns a provider config
+// whose clients send all requests to it, whatever the host of the request.
+func newAPIStubConfig(stub *apiStub) (*config.Config, *httptest.Server) {
+	server := httptest.NewServer(stub)
+	addr := server.Listener.Addr().String()
+
+	client := &golangsdk.ProviderClient{
+		ProjectID: stubProjectID,
+		HTTPClient: http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, network, addr)
+				},
+			},
+		},
+	}
+
+	cfg := &config.Config{
+		Region:    "ru-moscow-1",
+		AccessKey: stubAccessKey,
+		SecretKey: "stub-secret-key",
+		HwClient:  client,
+		Endpoints: map[string]string{
+			"cce":       server.URL + "/",
+			"cce_addon": server.URL + "/",
+			"smn":       server.URL + "/",
+			"obs":       server.URL + "/",
+		},
+		RegionProjectIDMap: map[string]string{"ru-moscow-1": stubProjectID},
+		RPLock:             new(sync.Mutex),
+	}
+	return cfg, server
+}
+
+func (s *apiStub) object(path string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[path]
+	return obj, ok
+}
+
+func (s *apiStub) subResource(bucket, name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, ok := s.buckets[bucket][name]
+	return body, ok
+}
+
+func (s *apiStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hosts = append(s.hosts, r.Host)
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/"):
+		s.serveCCE(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v2/"):
+		s.serveSMN(w, r)
+	default:
+		s.serveOBS(w, r)
+	}
+}
+
+func (s *apiStub) serveCCE(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if s.failCCE != nil {
+		if status := s.failCCE(r.Method, path); status != 0 {
+			writeStubJSON(w, status, map[string]interface{}{"message": "injected failure"})
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var obj map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			writeStubJSON(w, http.StatusBadRequest, map[string]interface{}{"message": err.Error()})
+			return
+		}
+
+		s.counter++
+		metadata, _ := obj["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+			obj["metadata"] = metadata
+		}
+		if prefix, ok := metadata["generateName"].(string); ok && prefix != "" {
+			metadata["name"] = fmt.Sprintf("%s%05d", prefix, s.counter)
+			delete(metadata, "generateName")
+		}
+		metadata["uid"] = fmt.Sprintf("1c1d32d1-0000-4000-8000-%012d", s.counter)
+		metadata["creationTimestamp"] = "2021-07-01T08:00:00Z"
+		obj["status"] = map[string]interface{}{"phase": "Active"}
+
+		key, _ := metadata["name"].(string)
+		if key == "" || !strings.HasPrefix(path, "/api/v1/") {
+			key = metadata["uid"].(string)
+		}
+		if _, ok := s.objects[path+"/"+key]; ok {
+			writeStubJSON(w, http.StatusConflict, map[string]interface{}{"message": key + " already exists"})
+			return
+		}
+		s.objects[path+"/"+key] = obj
+		s.changed(r.Method, path+"/"+key, obj)
+		writeStubJSON(w, http.StatusCreated, obj)
+
+	case http.MethodGet:
+		if obj, ok := s.objects[path]; ok {
+			writeStubJSON(w, http.StatusOK, obj)
+			return
+		}
+
+		var keys []string
+		for key := range s.objects {
+			if strings.HasPrefix(key, path+"/") && !strings.Contains(key[len(path)+1:], "/") {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			writeStubJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+			return
+		}
+		sort.Strings(keys)
+		items := make([]interface{}, len(keys))
+		for i, key := range keys {
+			items[i] = s.objects[key]
+		}
+		writeStubJSON(w, http.StatusOK, map[string]interface{}{"kind": "List", "items": items})
+
+	case http.MethodPut:
+		obj, ok := s.objects[path]
+		if !ok {
+			writeStubJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+			return
+		}
+		var update map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeStubJSON(w, http.StatusBadRequest, map[string]interface{}{"message": err.Error()})
+			return
+		}
+		obj["spec"] = update["spec"]
+		s.changed(r.Method, path, obj)
+		writeStubJSON(w, http.StatusOK, obj)
+
+	case http.MethodDelete:
+		obj, ok := s.objects[path]
+		if !ok {
+			writeStubJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+			return
+		}
+		delete(s.objects, path)
+		s.changed(r.Method, path, obj)
+		obj["status"] = map[string]interface{}{"phase": "Terminating"}
+		writeStubJSON(w, http.StatusOK, obj)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *apiStub) changed(method, path string, obj map[string]interface{}) {
+	if s.afterCCE != nil {
+		s.afterCCE(method, path, obj)
+	}
+}
+
+// serveSMN serves GET /v2/{project_id}/notifications/topics/{topic_urn}/attributes.
+func (s *apiStub) serveSMN(w http.ResponseWriter, r *http.Request) {
+	prefix := fmt.Sprintf("/v2/%s/notifications/topics/", stubProjectID)
+	urn := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), "/attributes")
+
+	policy, ok := s.topicPolicies[urn]
+	if r.Method != http.MethodGet || !ok {
+		writeStubJSON(w, http.StatusNotFound, map[string]string{"code": "SMN.00010008", "message": "topic not found"})
+		return
+	}
+	writeStubJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id": "6a63a18b8bab40ffb71ebd9cb80d0085",
+		"attributes": map[string]string{"access_policy": policy},
+	})
+}
+
+// serveOBS serves the sub-resources of the buckets, e.g. PUT /{bucket}?notification.
+func (s *apiStub) serveOBS(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Authorization"), " "+stubAccessKey+":") {
+		writeStubOBSError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	bucket := strings.Trim(r.URL.Path, "/")
+	subResources, ok := s.buckets[bucket]
+	if !ok {
+		writeStubOBSError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	name := r.URL.RawQuery
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		subResources[name] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := subResources[name]
+		if !ok {
+			writeStubOBSError(w, http.StatusNotFound, "NoSuchConfiguration")
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(body)
+	case http.MethodDelete:
+		delete(subResources, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// stubUpdateData returns the resource data to update the resource in the state
+// of d to the configuration raw, as planned by terraform.
+func stubUpdateData(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{},
+	meta interface{}) *schema.ResourceData {
+	state := d.State()
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("Error planning the update: %s", err)
+	}
+	data, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Error applying the plan: %s", err)
+	}
+	return data
+}
+
+func writeStubJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeStubOBSError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}